@@ -5,26 +5,34 @@ import (
 
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/ww24/linebot/domain/repository"
 	"github.com/ww24/linebot/internal/buildinfo"
 	"github.com/ww24/linebot/internal/config"
 	"github.com/ww24/linebot/tracer"
+	"github.com/ww24/linebot/usecase"
 )
 
 //nolint:gochecknoglobals
 var tc = tracer.NewConfig(serviceName, buildinfo.Version())
 
 type bot struct {
-	conf    *config.LINEBot
-	handler http.Handler
+	conf         *config.LINEBot
+	handler      http.Handler
+	scheduler    repository.ScheduleSynchronizer
+	eventHandler usecase.EventHandler
 }
 
 func newBot(
 	conf *config.LINEBot,
 	handler http.Handler,
+	scheduler repository.ScheduleSynchronizer,
+	eventHandler usecase.EventHandler,
 	_ trace.TracerProvider,
 ) *bot {
 	return &bot{
-		conf:    conf,
-		handler: handler,
+		conf:         conf,
+		handler:      handler,
+		scheduler:    scheduler,
+		eventHandler: eventHandler,
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/automaxprocs/maxprocs"
 	"google.golang.org/grpc/grpclog"
 
+	"github.com/ww24/linebot/infra/scheduler"
 	"github.com/ww24/linebot/internal/buildinfo"
 	"github.com/ww24/linebot/internal/gcp"
 	llog "github.com/ww24/linebot/log"
@@ -66,6 +67,11 @@ func main() {
 	}
 	defer cleanup()
 
+	// the local scheduler executes reminders in process instead of Cloud Tasks
+	if s, ok := bot.scheduler.(*scheduler.Local); ok {
+		s.Start(ctx, bot.eventHandler)
+	}
+
 	// initialize cloud profiler and tracing if build is production
 	if version := buildinfo.Version(); version != "" {
 		profilerConfig := profiler.Config{
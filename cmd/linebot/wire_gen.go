@@ -56,85 +56,123 @@ func register(contextContext context.Context) (*bot, func(), error) {
 		return nil, nil, err
 	}
 	conversation := firestore.NewConversation(client)
-	conversationImpl := service.NewConversation(conversation)
+	time, err := config.NewTime()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	conversationImpl := service.NewConversation(conversation, time)
+	cancel := interactor.NewCancel(conversationImpl, messageProviderSet, botImpl)
 	shopping := firestore.NewShopping(conversation)
-	shoppingImpl := service.NewShopping(conversation, shopping)
-	parser, err := nl.NewParser()
+	configShopping, err := config.NewShopping()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	shoppingImpl := service.NewShopping(conversation, shopping, configShopping)
+	configNL, err := config.NewNL()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	parser, err := nl.NewParser(configNL)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
 	interactorShopping := interactor.NewShopping(conversationImpl, shoppingImpl, parser, messageProviderSet, botImpl)
 	reminder := firestore.NewReminder(conversation)
-	schedulerScheduler, err := scheduler.New(contextContext, lineBot, serviceEndpoint)
+	configScheduler, err := config.NewScheduler()
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	reminderImpl := service.NewReminder(reminder, schedulerScheduler)
-	time, err := config.NewTime()
+	scheduleSynchronizer, cleanup2, err := scheduler.NewSynchronizer(contextContext, configScheduler, lineBot, serviceEndpoint)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	interactorReminder := interactor.NewReminder(conversationImpl, reminderImpl, messageProviderSet, botImpl, time)
+	reminderImpl := service.NewReminder(reminder, scheduleSynchronizer)
+	scheduleParser := nl.NewScheduleParser()
+	interactorReminder := interactor.NewReminder(conversationImpl, reminderImpl, shoppingImpl, scheduleParser, messageProviderSet, botImpl)
 	gcsClient, err := gcs.New(contextContext)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	storage, err := config.NewStorage()
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	weatherImageStore, err := gcs.NewWeatherImageStore(gcsClient, storage, time)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	weatherImpl, err := service.NewWeather(weatherImageStore, time, serviceEndpoint)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	weather := interactor.NewWeather(weatherImpl, messageProviderSet, botImpl)
-	eventHandler, err := interactor.NewEventHandler(interactorShopping, interactorReminder, weather, conversationImpl, reminderImpl, messageProviderSet, botImpl, lineBot)
+	weather := interactor.NewWeather(conversationImpl, weatherImpl, messageProviderSet, botImpl)
+	dictionary := firestore.NewDictionary(conversation)
+	intentClassifier, err := nl.NewIntentClassifier(parser, configNL)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dictionaryImpl := service.NewDictionary(dictionary, intentClassifier)
+	setting := interactor.NewSetting(conversationImpl, dictionaryImpl, messageProviderSet, botImpl)
+	help := interactor.NewHelp(messageProviderSet, botImpl)
+	eventHandler, err := interactor.NewEventHandler(cancel, interactorShopping, interactorReminder, weather, setting, help, conversationImpl, reminderImpl, dictionaryImpl, messageProviderSet, botImpl, lineBot, time)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	imageStore, err := gcs.NewImageStore(gcsClient, storage)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	image := interactor.NewImage(imageStore)
 	pubsubClient, err := pubsub.New(contextContext)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	accessLog, err := config.NewAccessLog()
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	publisher, cleanup2 := accesslog.NewPublisher(pubsubClient, accessLog)
+	publisher, cleanup3 := accesslog.NewPublisher(pubsubClient, accessLog)
 	sentry, err := config.NewSentry()
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	handler, err := http.NewHandler(botImpl, authorizer, eventHandler, image, publisher, accessLog, sentry)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	mainBot := newBot(lineBot, handler, tracerProvider)
+	mainBot := newBot(lineBot, handler, scheduleSynchronizer, eventHandler, tracerProvider)
 	return mainBot, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"golang.org/x/xerrors"
 )
@@ -14,6 +15,8 @@ const (
 	ConversationStatusTypeShopping
 	ConversationStatusTypeShoppingAdd
 	ConversationStatusTypeReminderAdd
+	ConversationStatusTypeReminderAddMessage
+	ConversationStatusTypeShoppingListAdd
 )
 
 func (t ConversationStatusType) valid() bool {
@@ -21,7 +24,9 @@ func (t ConversationStatusType) valid() bool {
 	case ConversationStatusTypeNeutral,
 		ConversationStatusTypeShopping,
 		ConversationStatusTypeShoppingAdd,
-		ConversationStatusTypeReminderAdd:
+		ConversationStatusTypeReminderAdd,
+		ConversationStatusTypeReminderAddMessage,
+		ConversationStatusTypeShoppingListAdd:
 		return true
 
 	default:
@@ -34,7 +39,10 @@ const (
 	conversationSeparateSize = 2
 )
 
-var ErrConversationStatusValidationFailed = errors.New("conversation status validation failed")
+var (
+	ErrConversationStatusValidationFailed = errors.New("conversation status validation failed")
+	ErrInvalidTimezone                    = errors.New("invalid timezone")
+)
 
 type ConversationID string
 
@@ -54,9 +62,44 @@ func (c ConversationID) String() string {
 	return string(c)
 }
 
+// ConversationStatusTTL is the period in which a flow such as adding shopping items is kept,
+// the conversation returns to neutral after it.
+const ConversationStatusTTL = 30 * time.Minute
+
 type ConversationStatus struct {
 	ConversationID ConversationID
 	Type           ConversationStatusType
+	// Payload holds a text input in the middle of a flow,
+	// e.g. the message of a reminder being added.
+	Payload string
+	// UpdatedAt is the unix time when the status was set.
+	UpdatedAt int64
+}
+
+// Expired reports whether the flow has passed the TTL at t,
+// a neutral status and the shopping mode which is left only by the user never expire.
+func (m *ConversationStatus) Expired(t time.Time) bool {
+	if m.Type == ConversationStatusTypeNeutral || m.Type == ConversationStatusTypeShopping {
+		return false
+	}
+	return !t.Before(time.Unix(m.UpdatedAt, 0).Add(ConversationStatusTTL))
+}
+
+// InputPending reports whether the flow takes the next text as its input such as the names of shopping items,
+// such a text is not a command.
+func (m *ConversationStatus) InputPending() bool {
+	switch m.Type {
+	case ConversationStatusTypeShoppingAdd,
+		ConversationStatusTypeShoppingListAdd,
+		ConversationStatusTypeReminderAddMessage:
+		return true
+	case ConversationStatusTypeNeutral,
+		ConversationStatusTypeShopping,
+		ConversationStatusTypeReminderAdd:
+		return false
+	default:
+		return false
+	}
 }
 
 func (m *ConversationStatus) Validate() error {
@@ -68,3 +111,39 @@ func (m *ConversationStatus) Validate() error {
 	}
 	return nil
 }
+
+// ConversationSetting holds preferences of the conversation.
+type ConversationSetting struct {
+	ConversationID ConversationID
+	// Timezone is an IANA time zone name, e.g. "Asia/Tokyo".
+	Timezone string
+	// ShoppingListID is the current shopping list.
+	ShoppingListID ShoppingListID
+	// Language is the language of the replies, Japanese is used if it is not set.
+	Language Language
+}
+
+// Location returns the time zone of the conversation or def if it is not set.
+func (s *ConversationSetting) Location(def *time.Location) *time.Location {
+	if s == nil || s.Timezone == "" {
+		return def
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return def
+	}
+	return loc
+}
+
+// LoadTimezone returns the location of the IANA time zone name.
+func LoadTimezone(name string) (*time.Location, error) {
+	// time.LoadLocation treats "" and "Local" as special names
+	if name == "" || name == "Local" {
+		return nil, xerrors.Errorf("empty timezone name: %w", ErrInvalidTimezone)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, xerrors.Errorf("%s: %w", err.Error(), ErrInvalidTimezone)
+	}
+	return loc, nil
+}
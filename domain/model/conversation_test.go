@@ -1,9 +1,12 @@
 package model
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConversationStatus_Validate(t *testing.T) {
@@ -33,7 +36,7 @@ func TestConversationStatus_Validate(t *testing.T) {
 			name: "invalid conversation status",
 			status: &ConversationStatus{
 				ConversationID: "invalid",
-				Type:           ConversationStatusType(4),
+				Type:           ConversationStatusType(-1),
 			},
 			want: ErrConversationStatusValidationFailed,
 		},
@@ -47,3 +50,134 @@ func TestConversationStatus_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConversationStatus_Expired(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status *ConversationStatus
+		want   bool
+	}{
+		{
+			name: "in progress",
+			status: &ConversationStatus{
+				Type:      ConversationStatusTypeShoppingAdd,
+				UpdatedAt: now.Add(-ConversationStatusTTL + time.Second).Unix(),
+			},
+			want: false,
+		},
+		{
+			name: "expired",
+			status: &ConversationStatus{
+				Type:      ConversationStatusTypeShoppingAdd,
+				UpdatedAt: now.Add(-ConversationStatusTTL).Unix(),
+			},
+			want: true,
+		},
+		{
+			name: "without updated at",
+			status: &ConversationStatus{
+				Type: ConversationStatusTypeReminderAdd,
+			},
+			want: true,
+		},
+		{
+			name: "neutral",
+			status: &ConversationStatus{
+				Type: ConversationStatusTypeNeutral,
+			},
+			want: false,
+		},
+		{
+			name: "shopping",
+			status: &ConversationStatus{
+				Type:      ConversationStatusTypeShopping,
+				UpdatedAt: now.Add(-ConversationStatusTTL).Unix(),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.status.Expired(now))
+		})
+	}
+}
+
+func TestConversationStatus_InputPending(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		typ  ConversationStatusType
+		want bool
+	}{
+		{typ: ConversationStatusTypeNeutral, want: false},
+		{typ: ConversationStatusTypeShopping, want: false},
+		{typ: ConversationStatusTypeShoppingAdd, want: true},
+		{typ: ConversationStatusTypeShoppingListAdd, want: true},
+		{typ: ConversationStatusTypeReminderAdd, want: false},
+		{typ: ConversationStatusTypeReminderAddMessage, want: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprint(tt.typ), func(t *testing.T) {
+			t.Parallel()
+			status := &ConversationStatus{Type: tt.typ}
+			assert.Equal(t, tt.want, status.InputPending())
+		})
+	}
+}
+
+func TestConversationSetting_Location(t *testing.T) {
+	t.Parallel()
+	def := time.FixedZone("Asia/Tokyo", 9*60*60)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		setting *ConversationSetting
+		want    *time.Location
+	}{
+		{name: "nil", setting: nil, want: def},
+		{name: "empty", setting: &ConversationSetting{}, want: def},
+		{name: "unknown", setting: &ConversationSetting{Timezone: "Unknown/Zone"}, want: def},
+		{name: "new york", setting: &ConversationSetting{Timezone: "America/New_York"}, want: newYork},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.setting.Location(def)
+			assert.Equal(t, tt.want.String(), got.String())
+		})
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "America/New_York", want: "America/New_York"},
+		{name: "UTC", want: "UTC"},
+		{name: "", wantErr: ErrInvalidTimezone},
+		{name: "Local", wantErr: ErrInvalidTimezone},
+		{name: "Unknown/Zone", wantErr: ErrInvalidTimezone},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadTimezone(tt.name)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
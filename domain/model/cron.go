@@ -0,0 +1,179 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	cronFieldSize = 5
+	// cronSearchDays limits the search for the next schedule,
+	// 8 years covers Feb 29 across a non-leap century year.
+	cronSearchDays = 8 * 366
+)
+
+type cronField struct {
+	min, max int
+}
+
+//nolint:gochecknoglobals
+var (
+	cronMinute     = cronField{min: 0, max: 59}
+	cronHour       = cronField{min: 0, max: 23}
+	cronDayOfMonth = cronField{min: 1, max: 31}
+	cronMonth      = cronField{min: 1, max: 12}
+	// 7 is also accepted as Sunday.
+	cronDayOfWeek = cronField{min: 0, max: 7}
+)
+
+// cronSpec is a parsed cron expression, each field is a bit set of allowed values.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar report the day fields are "*",
+	// both day fields are ORed if neither of them is "*".
+	domStar, dowStar bool
+}
+
+func parseCronSpec(spec string) (*cronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != cronFieldSize {
+		return nil, xerrors.Errorf("cron spec must have %d fields %q: %w", cronFieldSize, spec, ErrInvalidSchedule)
+	}
+
+	var (
+		s   cronSpec
+		err error
+	)
+	if s.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = cronDayOfMonth.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = cronDayOfWeek.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// fold 7 into Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return &s, nil
+}
+
+// parse parses a comma separated list of "*", "n", "n-m" with an optional "/step".
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, xerrors.Errorf("invalid cron step %q: %w", part, ErrInvalidSchedule)
+			}
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			lo, hi, _ := strings.Cut(rng, "-")
+			var err error
+			if start, err = f.atoi(lo); err != nil {
+				return 0, err
+			}
+			if end, err = f.atoi(hi); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, xerrors.Errorf("invalid cron range %q: %w", part, ErrInvalidSchedule)
+			}
+		default:
+			var err error
+			if start, err = f.atoi(rng); err != nil {
+				return 0, err
+			}
+			if !hasStep {
+				end = start
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) atoi(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to parse cron value: %w", err)
+	}
+	if n < f.min || n > f.max {
+		return 0, xerrors.Errorf("cron value %d out of range: %w", n, ErrInvalidSchedule)
+	}
+	return n, nil
+}
+
+func (s *cronSpec) matchDay(date time.Time) bool {
+	if s.month&(1<<uint(date.Month())) == 0 {
+		return false
+	}
+	dom := s.dom&(1<<uint(date.Day())) != 0
+	dow := s.dow&(1<<uint(date.Weekday())) != 0
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dow
+	case s.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// next returns the first scheduled time after t in the wall clock of loc.
+// Wall clock times skipped by a DST transition are skipped as well.
+func (s *cronSpec) next(t time.Time, loc *time.Location) (time.Time, error) {
+	year, month, day := t.In(loc).Date()
+	for i := 0; i < cronSearchDays; i++ {
+		// the calendar date is calculated in UTC to be free from DST
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, time.UTC)
+		if !s.matchDay(date) {
+			continue
+		}
+		for hour := cronHour.min; hour <= cronHour.max; hour++ {
+			if s.hour&(1<<uint(hour)) == 0 {
+				continue
+			}
+			for minute := cronMinute.min; minute <= cronMinute.max; minute++ {
+				if s.minute&(1<<uint(minute)) == 0 {
+					continue
+				}
+				target := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+				if target.Hour() != hour || target.Minute() != minute {
+					continue
+				}
+				if t.Before(target) {
+					return target, nil
+				}
+			}
+		}
+	}
+	return time.Time{}, ErrEndSchedule
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// deliveryTimeout is the duration after which a pending delivery is regarded as abandoned.
+const deliveryTimeout = 10 * time.Minute
+
+type DeliveryOutcome int
+
+const (
+	// DeliveryOutcomePending means the delivery is in progress.
+	DeliveryOutcomePending DeliveryOutcome = iota
+	DeliveryOutcomeSent
+	DeliveryOutcomeFailed
+)
+
+func (o DeliveryOutcome) String() string {
+	switch o {
+	case DeliveryOutcomePending:
+		return "pending"
+	case DeliveryOutcomeSent:
+		return "sent"
+	case DeliveryOutcomeFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Delivery is a log of an execution of the reminder item for a scheduled time.
+type Delivery struct {
+	ConversationID ConversationID
+	ItemID         ReminderItemID
+	ScheduledAt    time.Time
+	StartedAt      time.Time
+	SentAt         time.Time
+	Outcome        DeliveryOutcome
+	Error          string
+}
+
+func NewDelivery(item *ReminderItem, scheduledAt, t time.Time) *Delivery {
+	return &Delivery{
+		ConversationID: item.ConversationID,
+		ItemID:         item.ID,
+		ScheduledAt:    scheduledAt,
+		StartedAt:      t,
+		Outcome:        DeliveryOutcomePending,
+	}
+}
+
+// ID returns an identifier of the item and the scheduled time.
+func (d *Delivery) ID() string {
+	return string(d.ItemID) + "-" + strconv.FormatInt(d.ScheduledAt.Unix(), 10)
+}
+
+// Finish records the outcome of the delivery finished at t.
+func (d *Delivery) Finish(t time.Time, err error) {
+	if err != nil {
+		d.Outcome = DeliveryOutcomeFailed
+		d.Error = err.Error()
+		return
+	}
+	d.Outcome = DeliveryOutcomeSent
+	d.SentAt = t
+	d.Error = ""
+}
+
+// Blocks reports whether another delivery for the same slot must be skipped at t.
+// A failed or abandoned delivery can be retried.
+func (d *Delivery) Blocks(t time.Time) bool {
+	switch d.Outcome {
+	case DeliveryOutcomeSent:
+		return true
+	case DeliveryOutcomePending:
+		return t.Before(d.StartedAt.Add(deliveryTimeout))
+	default:
+		return false
+	}
+}
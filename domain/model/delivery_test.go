@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelivery_ID(t *testing.T) {
+	t.Parallel()
+	item := &ReminderItem{ConversationID: "LU-test", ID: "item1"}
+	d := NewDelivery(item, time.Unix(1700000000, 0), time.Unix(1700000001, 0))
+	assert.Equal(t, "item1-1700000000", d.ID())
+}
+
+func TestDelivery_Finish(t *testing.T) {
+	t.Parallel()
+	item := &ReminderItem{ConversationID: "LU-test", ID: "item1"}
+	now := time.Unix(1700000000, 0)
+
+	d := NewDelivery(item, now, now)
+	d.Finish(now.Add(time.Second), errors.New("push failed"))
+	assert.Equal(t, DeliveryOutcomeFailed, d.Outcome)
+	assert.Equal(t, "push failed", d.Error)
+	assert.True(t, d.SentAt.IsZero())
+
+	d.Finish(now.Add(2*time.Second), nil)
+	assert.Equal(t, DeliveryOutcomeSent, d.Outcome)
+	assert.Empty(t, d.Error)
+	assert.Equal(t, now.Add(2*time.Second), d.SentAt)
+}
+
+func TestDelivery_Blocks(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name    string
+		outcome DeliveryOutcome
+		t       time.Time
+		want    bool
+	}{
+		{name: "sent", outcome: DeliveryOutcomeSent, t: now.Add(time.Hour), want: true},
+		{name: "pending", outcome: DeliveryOutcomePending, t: now.Add(time.Minute), want: true},
+		{name: "abandoned", outcome: DeliveryOutcomePending, t: now.Add(deliveryTimeout), want: false},
+		{name: "failed", outcome: DeliveryOutcomeFailed, t: now.Add(time.Minute), want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := &Delivery{StartedAt: now, Outcome: tt.outcome}
+			assert.Equal(t, tt.want, d.Blocks(tt.t))
+		})
+	}
+}
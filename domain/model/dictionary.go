@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/xerrors"
+)
+
+var ErrInvalidUserWord = errors.New("invalid user word")
+
+// UserWord is a word which a conversation teaches the tokenizer, e.g. "オイコス".
+type UserWord struct {
+	ConversationID ConversationID
+	// Surface is the word as it is written.
+	Surface string
+	// Reading is the reading of the word in katakana.
+	Reading   string
+	CreatedAt int64
+}
+
+// NewUserWord returns the word, the reading may be written in hiragana.
+func NewUserWord(conversationID ConversationID, surface, reading string, t time.Time) (*UserWord, error) {
+	w := &UserWord{
+		ConversationID: conversationID,
+		Surface:        strings.TrimSpace(norm.NFKC.String(surface)),
+		Reading:        toKatakana(strings.TrimSpace(norm.NFKC.String(reading))),
+		CreatedAt:      t.Unix(),
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *UserWord) Validate() error {
+	if w.ConversationID == "" {
+		return xerrors.Errorf("invalid empty conversation id: %w", ErrInvalidUserWord)
+	}
+	if w.Surface == "" || strings.ContainsAny(w.Surface, ", /") {
+		return xerrors.Errorf("invalid surface %q: %w", w.Surface, ErrInvalidUserWord)
+	}
+	if w.Reading == "" {
+		return xerrors.Errorf("invalid empty reading: %w", ErrInvalidUserWord)
+	}
+	for _, r := range w.Reading {
+		if !unicode.Is(unicode.Katakana, r) && r != 'ー' {
+			return xerrors.Errorf("invalid reading %q: %w", w.Reading, ErrInvalidUserWord)
+		}
+	}
+	return nil
+}
+
+type UserWords []*UserWord
+
+// Print prints the words with the readings.
+func (l UserWords) Print() string {
+	lines := make([]string, 0, len(l))
+	for _, w := range l {
+		lines = append(lines, "・"+w.Surface+"（"+w.Reading+"）")
+	}
+	return strings.Join(lines, "\n")
+}
+
+const (
+	hiraganaSmallA = 'ぁ'
+	hiraganaVu     = 'ゔ'
+	kanaOffset     = 'ァ' - 'ぁ'
+)
+
+func toKatakana(str string) string {
+	return strings.Map(func(r rune) rune {
+		if hiraganaSmallA <= r && r <= hiraganaVu {
+			return r + kanaOffset
+		}
+		return r
+	}, str)
+}
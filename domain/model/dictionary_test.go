@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserWord(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1666416720, 0)
+	tests := []struct {
+		name        string
+		surface     string
+		reading     string
+		wantSurface string
+		wantReading string
+		wantErr     bool
+	}{
+		{name: "katakana", surface: "オイコス", reading: "オイコス", wantSurface: "オイコス", wantReading: "オイコス"},
+		{name: "hiragana reading", surface: "キッチンハイター", reading: "きっちんはいたー", wantSurface: "キッチンハイター", wantReading: "キッチンハイター"},
+		{name: "half width", surface: " ｵｲｺｽ ", reading: "ｵｲｺｽ", wantSurface: "オイコス", wantReading: "オイコス"},
+		{name: "kanji reading", surface: "午後ティー", reading: "午後", wantErr: true},
+		{name: "empty reading", surface: "オイコス", reading: "", wantErr: true},
+		{name: "separator", surface: "オイコス,2", reading: "オイコス", wantErr: true},
+		{name: "space", surface: "オイコス ヨーグルト", reading: "オイコス", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewUserWord("c1", tt.surface, tt.reading, now)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidUserWord)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSurface, got.Surface)
+			assert.Equal(t, tt.wantReading, got.Reading)
+		})
+	}
+}
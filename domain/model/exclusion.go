@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// maxExcludedSchedules limits the number of skipped schedules in a single Next call.
+	maxExcludedSchedules = 10000
+)
+
+var (
+	ErrInvalidDateRange = errors.New("invalid date range")
+	// ErrTooManyExcludedSchedules means the next schedule is too far to find,
+	// the schedule has not ended and a later call may find it.
+	ErrTooManyExcludedSchedules = errors.New("too many excluded schedules")
+)
+
+// Exclusion skips schedules of the Scheduler, see ReminderItem.Next.
+type Exclusion struct {
+	// Holidays skips Japanese national holidays.
+	Holidays bool
+	// BusinessDays fires only on weekdays which are not Japanese national holidays.
+	BusinessDays bool
+	// Ranges skips the dates in the ranges.
+	Ranges []*DateRange
+}
+
+// Empty reports whether the exclusion skips nothing.
+func (e *Exclusion) Empty() bool {
+	return e == nil || (!e.Holidays && !e.BusinessDays && len(e.Ranges) == 0)
+}
+
+// Excludes reports whether the date of t in its location is skipped.
+func (e *Exclusion) Excludes(t time.Time) bool {
+	if e.Empty() {
+		return false
+	}
+	_, holiday := HolidayJP(t)
+	if e.Holidays && holiday {
+		return true
+	}
+	if e.BusinessDays && (holiday || t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	date := t.Format(dateLayout)
+	for _, r := range e.Ranges {
+		if r.contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// UIText returns a text for UI.
+func (e *Exclusion) UIText() string {
+	if e.Empty() {
+		return ""
+	}
+	texts := make([]string, 0, len(e.Ranges)+1)
+	switch {
+	case e.BusinessDays:
+		texts = append(texts, "business days only")
+	case e.Holidays:
+		texts = append(texts, "except holidays")
+	}
+	for _, r := range e.Ranges {
+		texts = append(texts, "except "+r.String())
+	}
+	return strings.Join(texts, ", ") + "."
+}
+
+// DateRange is an inclusive range of dates.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewDateRange returns a range from the date of start to the date of end.
+func NewDateRange(start, end time.Time) (*DateRange, error) {
+	r := &DateRange{
+		Start: dateOf(start),
+		End:   dateOf(end),
+	}
+	if r.End.Before(r.Start) {
+		return nil, xerrors.Errorf("%s: %w", r, ErrInvalidDateRange)
+	}
+	return r, nil
+}
+
+// ParseDateRange parses "2006-01-02/2006-01-02".
+func ParseDateRange(s string) (*DateRange, error) {
+	start, end, ok := strings.Cut(s, "/")
+	if !ok {
+		return nil, xerrors.Errorf("%q: %w", s, ErrInvalidDateRange)
+	}
+	st, err := time.Parse(dateLayout, start)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse start date: %w", err)
+	}
+	et, err := time.Parse(dateLayout, end)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse end date: %w", err)
+	}
+	return NewDateRange(st, et)
+}
+
+func (r *DateRange) String() string {
+	return r.Start.Format(dateLayout) + "/" + r.End.Format(dateLayout)
+}
+
+func (r *DateRange) contains(date string) bool {
+	// the layout is ordered lexicographically
+	return r.Start.Format(dateLayout) <= date && date <= r.End.Format(dateLayout)
+}
+
+// dateOf returns the date of t in its location as UTC midnight.
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidayJP(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tests := []struct {
+		date     time.Time
+		wantName string
+		wantOK   bool
+	}{
+		{date: time.Date(2025, 1, 1, 8, 0, 0, 0, loc), wantName: "元日", wantOK: true},
+		{date: time.Date(2026, 9, 22, 8, 0, 0, 0, loc), wantName: "休日", wantOK: true},
+		{date: time.Date(2026, 9, 24, 8, 0, 0, 0, loc), wantOK: false},
+		// the special holidays of the calendar
+		{date: time.Date(2019, 5, 1, 8, 0, 0, 0, loc), wantName: "休日（祝日扱い）", wantOK: true},
+		{date: time.Date(2021, 8, 8, 8, 0, 0, 0, loc), wantName: "山の日", wantOK: true},
+		{date: time.Date(2021, 8, 11, 8, 0, 0, 0, loc), wantOK: false},
+		// after the published calendar
+		{date: time.Date(2028, 3, 20, 8, 0, 0, 0, loc), wantName: "春分の日", wantOK: true},
+		{date: time.Date(2029, 2, 12, 8, 0, 0, 0, loc), wantName: "休日", wantOK: true},
+		{date: time.Date(2030, 1, 14, 8, 0, 0, 0, loc), wantName: "成人の日", wantOK: true},
+		// the date in UTC is not a holiday but it is in Tokyo
+		{date: time.Date(2025, 1, 1, 8, 0, 0, 0, loc).In(time.UTC), wantOK: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.date.String(), func(t *testing.T) {
+			t.Parallel()
+			name, ok := HolidayJP(tt.date)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestHolidayJPByRule(t *testing.T) {
+	t.Parallel()
+	want := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(holidaysJPCSV), "\n") {
+		date, name, ok := strings.Cut(line, ",")
+		require.True(t, ok)
+		want[date] = name
+	}
+
+	// the rules agree with the calendar since 2022, the earlier years have the special holidays
+	for d := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC); d.Year() <= 2027; d = d.AddDate(0, 0, 1) {
+		name, ok := holidayJPByRule(d)
+		wantName, wantOK := want[d.Format(dateLayout)]
+		assert.Equal(t, wantOK, ok, d.Format(dateLayout))
+		assert.Equal(t, wantName, name, d.Format(dateLayout))
+	}
+}
+
+func TestExclusion_Excludes(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	dateRange, err := ParseDateRange("2025-08-10/2025-08-15")
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		exclusion *Exclusion
+		date      time.Time
+		want      bool
+	}{
+		{name: "nil", exclusion: nil, date: time.Date(2025, 1, 1, 8, 0, 0, 0, loc), want: false},
+		{name: "holiday", exclusion: &Exclusion{Holidays: true}, date: time.Date(2025, 1, 1, 8, 0, 0, 0, loc), want: true},
+		{name: "not holiday", exclusion: &Exclusion{Holidays: true}, date: time.Date(2025, 1, 4, 8, 0, 0, 0, loc), want: false},
+		{name: "business day", exclusion: &Exclusion{BusinessDays: true}, date: time.Date(2025, 1, 6, 8, 0, 0, 0, loc), want: false},
+		{name: "saturday", exclusion: &Exclusion{BusinessDays: true}, date: time.Date(2025, 1, 4, 8, 0, 0, 0, loc), want: true},
+		{name: "weekday holiday", exclusion: &Exclusion{BusinessDays: true}, date: time.Date(2025, 1, 13, 8, 0, 0, 0, loc), want: true},
+		{name: "first day of range", exclusion: &Exclusion{Ranges: []*DateRange{dateRange}}, date: time.Date(2025, 8, 10, 0, 0, 0, 0, loc), want: true},
+		{name: "last day of range", exclusion: &Exclusion{Ranges: []*DateRange{dateRange}}, date: time.Date(2025, 8, 15, 23, 59, 0, 0, loc), want: true},
+		{name: "out of range", exclusion: &Exclusion{Ranges: []*DateRange{dateRange}}, date: time.Date(2025, 8, 16, 0, 0, 0, 0, loc), want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.exclusion.Excludes(tt.date)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExclusion_UIText(t *testing.T) {
+	t.Parallel()
+	dateRange, err := ParseDateRange("2025-08-10/2025-08-15")
+	require.NoError(t, err)
+	assert.Equal(t, "", (*Exclusion)(nil).UIText())
+	assert.Equal(t, "except holidays.", (&Exclusion{Holidays: true}).UIText())
+	assert.Equal(t, "business days only, except 2025-08-10/2025-08-15.",
+		(&Exclusion{Holidays: true, BusinessDays: true, Ranges: []*DateRange{dateRange}}).UIText())
+}
+
+func TestParseDateRange(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s       string
+		wantErr error
+	}{
+		{s: "2025-08-10/2025-08-15"},
+		{s: "2025-08-10/2025-08-10"},
+		{s: "2025-08-15/2025-08-10", wantErr: ErrInvalidDateRange},
+		{s: "2025-08-10", wantErr: ErrInvalidDateRange},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDateRange(tt.s)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.s, got.String())
+		})
+	}
+}
+
+func TestReminderItem_Next(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	dateRange, err := ParseDateRange("2025-08-10/2025-08-15")
+	require.NoError(t, err)
+	daily := &DailyScheduler{Time: time.Date(2025, 1, 1, 8, 0, 0, 0, loc)}
+	tests := []struct {
+		name    string
+		item    *ReminderItem
+		now     time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "no exclusion",
+			item: &ReminderItem{Scheduler: daily},
+			now:  time.Date(2024, 12, 31, 9, 0, 0, 0, loc),
+			want: time.Date(2025, 1, 1, 8, 0, 0, 0, loc),
+		},
+		{
+			name: "skip holidays",
+			item: &ReminderItem{Scheduler: daily, Exclusion: &Exclusion{Holidays: true}},
+			now:  time.Date(2024, 12, 31, 9, 0, 0, 0, loc),
+			want: time.Date(2025, 1, 2, 8, 0, 0, 0, loc),
+		},
+		{
+			name: "business days",
+			item: &ReminderItem{Scheduler: daily, Exclusion: &Exclusion{BusinessDays: true}},
+			// Friday, the next Monday is a holiday
+			now:  time.Date(2025, 1, 10, 9, 0, 0, 0, loc),
+			want: time.Date(2025, 1, 14, 8, 0, 0, 0, loc),
+		},
+		{
+			name: "skip date range",
+			item: &ReminderItem{Scheduler: daily, Exclusion: &Exclusion{Ranges: []*DateRange{dateRange}}},
+			now:  time.Date(2025, 8, 9, 9, 0, 0, 0, loc),
+			want: time.Date(2025, 8, 16, 8, 0, 0, 0, loc),
+		},
+		{
+			name: "oneshot on a holiday",
+			item: &ReminderItem{
+				Scheduler: &OneshotScheduler{Time: time.Date(2025, 1, 1, 8, 0, 0, 0, loc)},
+				Exclusion: &Exclusion{Holidays: true},
+			},
+			now:     time.Date(2024, 12, 31, 9, 0, 0, 0, loc),
+			wantErr: ErrEndSchedule,
+		},
+		{
+			name: "too many excluded schedules",
+			item: &ReminderItem{
+				Scheduler: daily,
+				Exclusion: &Exclusion{Ranges: []*DateRange{{
+					Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2060, 12, 31, 0, 0, 0, 0, time.UTC),
+				}}},
+			},
+			now:     time.Date(2024, 12, 31, 9, 0, 0, 0, loc),
+			wantErr: ErrTooManyExcludedSchedules,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.item.Next(tt.now)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	holidayNameSubstitute = "休日"
+	daysInWeek            = 7
+	// equinoxBaseYear and equinoxYearLength are the parameters of the equinox formula, it is valid until 2099.
+	equinoxBaseYear   = 1980
+	equinoxYearLength = 0.242194
+	equinoxLeapCycle  = 4
+)
+
+//nolint:gochecknoglobals
+var (
+	// holidaysJPCSV is the calendar published by the Cabinet Office.
+	//go:embed holidays_jp.csv
+	holidaysJPCSV string
+
+	holidaysJPOnce sync.Once
+	holidaysJP     map[string]string
+	// holidaysJPFirstYear and holidaysJPLastYear are the years of the calendar.
+	holidaysJPFirstYear, holidaysJPLastYear int
+)
+
+func loadHolidaysJP() {
+	holidaysJP = make(map[string]string)
+	for _, line := range strings.Split(holidaysJPCSV, "\n") {
+		date, name, ok := strings.Cut(strings.TrimSpace(line), ",")
+		if !ok {
+			continue
+		}
+		holidaysJP[date] = name
+		d, err := time.Parse(dateLayout, date)
+		if err != nil {
+			continue
+		}
+		if holidaysJPFirstYear == 0 || d.Year() < holidaysJPFirstYear {
+			holidaysJPFirstYear = d.Year()
+		}
+		if d.Year() > holidaysJPLastYear {
+			holidaysJPLastYear = d.Year()
+		}
+	}
+}
+
+// holidayRuleJP is a rule of a national holiday, the day is a fixed day, the nth Monday or the equinox.
+type holidayRuleJP struct {
+	name  string
+	month time.Month
+	// day is the fixed day of the month.
+	day int
+	// monday is n of the nth Monday of the month, such as 2 of "成人の日".
+	monday int
+	// equinox is the constant of the equinox formula of the month.
+	equinox float64
+}
+
+// holidayRulesJP are the rules of the current law.
+//
+//nolint:gochecknoglobals
+var holidayRulesJP = []holidayRuleJP{
+	{name: "元日", month: time.January, day: 1},
+	{name: "成人の日", month: time.January, monday: 2},
+	{name: "建国記念の日", month: time.February, day: 11},
+	{name: "天皇誕生日", month: time.February, day: 23},
+	{name: "春分の日", month: time.March, equinox: 20.8431},
+	{name: "昭和の日", month: time.April, day: 29},
+	{name: "憲法記念日", month: time.May, day: 3},
+	{name: "みどりの日", month: time.May, day: 4},
+	{name: "こどもの日", month: time.May, day: 5},
+	{name: "海の日", month: time.July, monday: 3},
+	{name: "山の日", month: time.August, day: 11},
+	{name: "敬老の日", month: time.September, monday: 3},
+	{name: "秋分の日", month: time.September, equinox: 23.2488},
+	{name: "スポーツの日", month: time.October, monday: 2},
+	{name: "文化の日", month: time.November, day: 3},
+	{name: "勤労感謝の日", month: time.November, day: 23},
+}
+
+func (r *holidayRuleJP) dayOf(year int) int {
+	switch {
+	case r.monday > 0:
+		first := time.Date(year, r.month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		return 1 + (int(time.Monday)-int(first)+daysInWeek)%daysInWeek + daysInWeek*(r.monday-1)
+	case r.equinox > 0:
+		years := year - equinoxBaseYear
+		return int(r.equinox+equinoxYearLength*float64(years)) - years/equinoxLeapCycle
+	default:
+		return r.day
+	}
+}
+
+// HolidayJP returns the name of the Japanese national holiday of the date of t in its location.
+// The embedded calendar is the source of truth, the years out of it are computed by holidayJPByRule.
+func HolidayJP(t time.Time) (string, bool) {
+	holidaysJPOnce.Do(loadHolidaysJP)
+	if year := t.Year(); holidaysJPFirstYear <= year && year <= holidaysJPLastYear {
+		name, ok := holidaysJP[t.Format(dateLayout)]
+		return name, ok
+	}
+	return holidayJPByRule(t)
+}
+
+// holidayJPByRule computes the holiday by the rules of the current law including the substitute holidays
+// and the days between two holidays, the special holidays announced for a year are not included.
+func holidayJPByRule(t time.Time) (string, bool) {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if name, ok := nationalHolidayJP(date); ok {
+		return name, true
+	}
+
+	// the first day after a holiday on Sunday which is not a holiday is a substitute holiday
+	for prev := date.AddDate(0, 0, -1); ; prev = prev.AddDate(0, 0, -1) {
+		if _, ok := nationalHolidayJP(prev); !ok {
+			break
+		}
+		if prev.Weekday() == time.Sunday {
+			return holidayNameSubstitute, true
+		}
+	}
+
+	// a day between two holidays is a holiday
+	_, prev := nationalHolidayJP(date.AddDate(0, 0, -1))
+	_, next := nationalHolidayJP(date.AddDate(0, 0, 1))
+	if prev && next {
+		return holidayNameSubstitute, true
+	}
+
+	return "", false
+}
+
+func nationalHolidayJP(date time.Time) (string, bool) {
+	for i := range holidayRulesJP {
+		rule := &holidayRulesJP[i]
+		if rule.month == date.Month() && rule.dayOf(date.Year()) == date.Day() {
+			return rule.name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,35 @@
+package model
+
+// IntentType is what a user wants to do with a text message.
+type IntentType int
+
+const (
+	IntentTypeUnknown IntentType = iota
+	// IntentTypeShowList shows the shopping list such as "買い物リストを見せて".
+	IntentTypeShowList
+	// IntentTypeAddItems adds items to the shopping list such as "牛乳と卵を買い物リストに追加".
+	IntentTypeAddItems
+	// IntentTypeDelete deletes items such as "1番を削除".
+	IntentTypeDelete
+	// IntentTypeCheck marks items as purchased such as "牛乳を買った".
+	IntentTypeCheck
+	// IntentTypeUpdate renames an item such as "2番をバター無塩に変更".
+	IntentTypeUpdate
+	// IntentTypeShowReminders shows the reminders such as "リマインダー".
+	IntentTypeShowReminders
+	// IntentTypeSetReminder sets a reminder such as "明日の8時に買い物リストをリマインド".
+	IntentTypeSetReminder
+	// IntentTypeWeather shows the weather such as "今日の天気は？".
+	IntentTypeWeather
+	// IntentTypeHelp shows the usage such as "ヘルプ".
+	IntentTypeHelp
+)
+
+// Intent is a classified text message with the slots extracted from the text.
+type Intent struct {
+	Type IntentType
+	// Item is the parsed text, it holds the references and the names of IntentTypeDelete, IntentTypeCheck and IntentTypeUpdate.
+	Item *Item
+	// Items are the item texts of IntentTypeAddItems such as "卵2パック".
+	Items []string
+}
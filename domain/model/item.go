@@ -5,12 +5,29 @@ type ActionType int
 const (
 	ActionTypeUnknown ActionType = iota
 	ActionTypeDelete
+	// ActionTypeCheck marks items as purchased.
+	ActionTypeCheck
+	// ActionTypeUpdate renames an item.
+	ActionTypeUpdate
 )
 
+// IndexRange is a range of indexes such as "1から3番", both ends are included.
+type IndexRange struct {
+	From int
+	To   int
+}
+
 type Item struct {
+	// Indexes are the 1-based numbers of items, a negative index counts from the end, e.g. -1 is the last item.
 	Indexes []int
-	Name    []string
-	Action  ActionType
+	// Ranges are the ranges of indexes, the ends are the same as Indexes.
+	Ranges []IndexRange
+	// All reports whether every item is referred such as "全部".
+	All    bool
+	Name   []string
+	Action ActionType
+	// NewName is the new text of the item for ActionTypeUpdate, e.g. "バター無塩" of "2番をバター無塩に変更".
+	NewName string
 }
 
 func (i *Item) UniqueIndexes() []int {
@@ -24,3 +41,46 @@ func (i *Item) UniqueIndexes() []int {
 	}
 	return indexes
 }
+
+// ResolveIndexes returns the unique 1-based indexes of n items in order,
+// the relative indexes and the ranges are resolved and the indexes out of the items are omitted.
+func (i *Item) ResolveIndexes(n int) []int {
+	resolve := func(index int) int {
+		if index < 0 {
+			return n + 1 + index
+		}
+		return index
+	}
+
+	indexes := make([]int, 0)
+	set := make(map[int]struct{})
+	add := func(index int) {
+		if index <= 0 || index > n {
+			return
+		}
+		if _, ok := set[index]; !ok {
+			indexes = append(indexes, index)
+			set[index] = struct{}{}
+		}
+	}
+	if i.All {
+		for index := 1; index <= n; index++ {
+			add(index)
+		}
+		return indexes
+	}
+	for _, index := range i.Indexes {
+		add(resolve(index))
+	}
+	for _, r := range i.Ranges {
+		from, to := resolve(r.From), resolve(r.To)
+		if from > to {
+			from, to = to, from
+		}
+		from, to = max(from, 1), min(to, n)
+		for index := from; index <= to; index++ {
+			add(index)
+		}
+	}
+	return indexes
+}
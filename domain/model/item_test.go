@@ -52,3 +52,62 @@ func TestItem_UniqueIndexes(t *testing.T) {
 		})
 	}
 }
+
+func TestItem_ResolveIndexes(t *testing.T) {
+	t.Parallel()
+	const n = 5
+	tests := []struct {
+		name string
+		item *Item
+		want []int
+	}{
+		{
+			name: "no reference",
+			item: &Item{},
+			want: []int{},
+		},
+		{
+			name: "out of the items",
+			item: &Item{Indexes: []int{0, 1, 6}},
+			want: []int{1},
+		},
+		{
+			name: "relative indexes",
+			item: &Item{Indexes: []int{-1, 1, -2}},
+			want: []int{5, 1, 4},
+		},
+		{
+			name: "ranges",
+			item: &Item{
+				Indexes: []int{2},
+				Ranges:  []IndexRange{{From: 1, To: 3}, {From: 5, To: 4}},
+			},
+			want: []int{2, 1, 3, 4, 5},
+		},
+		{
+			name: "range to the last",
+			item: &Item{Ranges: []IndexRange{{From: 3, To: -1}}},
+			want: []int{3, 4, 5},
+		},
+		{
+			name: "range over the items",
+			item: &Item{Ranges: []IndexRange{{From: 4, To: 100000000}}},
+			want: []int{4, 5},
+		},
+		{
+			name: "all",
+			item: &Item{All: true, Indexes: []int{3}},
+			want: []int{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.item.ResolveIndexes(n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got: %+v, want: %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/xerrors"
+)
+
+// Language is the language of the replies and the texts of a conversation.
+type Language string
+
+const (
+	LanguageJapanese Language = "ja"
+	LanguageEnglish  Language = "en"
+)
+
+var ErrInvalidLanguage = errors.New("invalid language")
+
+// ParseLanguage returns the language of the code or the name such as "en", "English" and "英語".
+func ParseLanguage(s string) (Language, error) {
+	switch strings.ToLower(strings.TrimSpace(norm.NFKC.String(s))) {
+	case "ja", "japanese", "日本語":
+		return LanguageJapanese, nil
+	case "en", "english", "英語":
+		return LanguageEnglish, nil
+	default:
+		return "", xerrors.Errorf("%q: %w", s, ErrInvalidLanguage)
+	}
+}
+
+// OrDefault returns Japanese if the language is not set or unknown.
+func (l Language) OrDefault() Language {
+	switch l {
+	case LanguageJapanese, LanguageEnglish:
+		return l
+	default:
+		return LanguageJapanese
+	}
+}
+
+// Tag returns the BCP 47 tag of the language.
+func (l Language) Tag() language.Tag {
+	switch l.OrDefault() {
+	case LanguageEnglish:
+		return language.English
+	default:
+		return language.Japanese
+	}
+}
+
+// Name returns the name of the language written in the language itself.
+func (l Language) Name() string {
+	switch l.OrDefault() {
+	case LanguageEnglish:
+		return "English"
+	default:
+		return "日本語"
+	}
+}
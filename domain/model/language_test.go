@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseLanguage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		src     string
+		want    Language
+		wantErr error
+	}{
+		{src: "ja", want: LanguageJapanese},
+		{src: "日本語", want: LanguageJapanese},
+		{src: "Japanese", want: LanguageJapanese},
+		{src: "EN", want: LanguageEnglish},
+		{src: " english ", want: LanguageEnglish},
+		{src: "英語", want: LanguageEnglish},
+		{src: "ｅｎ", want: LanguageEnglish},
+		{src: "fr", wantErr: ErrInvalidLanguage},
+		{src: "", wantErr: ErrInvalidLanguage},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseLanguage(tt.src)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLanguage_OrDefault(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, LanguageJapanese, Language("").OrDefault())
+	assert.Equal(t, LanguageJapanese, Language("fr").OrDefault())
+	assert.Equal(t, LanguageEnglish, LanguageEnglish.OrDefault())
+	assert.Equal(t, language.English, LanguageEnglish.Tag())
+	assert.Equal(t, language.Japanese, Language("").Tag())
+}
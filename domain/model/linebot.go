@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/line/line-bot-sdk-go/v7/linebot"
 )
@@ -10,6 +11,12 @@ import (
 type Event struct {
 	*linebot.Event
 	Status *ConversationStatus
+	// Location is the time zone of the conversation.
+	Location *time.Location
+	// Language is the language of the conversation.
+	Language Language
+	// Intent is the classified text message, the type is IntentTypeUnknown for the events without text.
+	Intent *Intent
 }
 
 // ConversationID returns conversation ID.
@@ -47,6 +54,14 @@ func (e *Event) HandleTypePostback(ctx context.Context, f func(context.Context,
 	return nil
 }
 
+// IntentType returns the type of Intent, it returns IntentTypeUnknown if the intent is not classified.
+func (e *Event) IntentType() IntentType {
+	if e.Intent == nil {
+		return IntentTypeUnknown
+	}
+	return e.Intent.Type
+}
+
 // FilterText returns true if Event.Message contains target text.
 func (e *Event) FilterText(target string) bool {
 	text, ok := e.Message.(*linebot.TextMessage)
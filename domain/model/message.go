@@ -6,6 +6,8 @@ const (
 	ShoppingReplyTypeAll ShoppingReplyType = iota
 	ShoppingReplyTypeEmptyList
 	ShoppingReplyTypeWithoutView
+	// ShoppingReplyTypeWithChecked is ShoppingReplyTypeWithoutView with clearing purchased items.
+	ShoppingReplyTypeWithChecked
 )
 
 type ReminderReplyType int
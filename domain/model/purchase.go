@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+const (
+	// minSuggestionCount is the number of purchases to regard an item as a usual item.
+	minSuggestionCount = 2
+	// purchaseMergeWindow merges additions of an item in a short period into a purchase.
+	purchaseMergeWindow = 24 * time.Hour
+)
+
+type PurchaseEventType int
+
+const (
+	// PurchaseEventTypeAdded means the item is added to a shopping list.
+	PurchaseEventTypeAdded PurchaseEventType = iota + 1
+	// PurchaseEventTypeCleared means the item is removed from a shopping list.
+	PurchaseEventTypeCleared
+)
+
+// PurchaseRecord is an append-only history entry of a shopping item.
+type PurchaseRecord struct {
+	ID             string
+	ConversationID ConversationID
+	Name           string
+	Quantity       int
+	Unit           string
+	Category       string
+	Event          PurchaseEventType
+	At             int64
+}
+
+// NewPurchaseRecords returns the history entries of the items for the event at t.
+func NewPurchaseRecords(items ShoppingItems, event PurchaseEventType, t time.Time) PurchaseRecords {
+	records := make(PurchaseRecords, 0, len(items))
+	for _, item := range items {
+		records = append(records, &PurchaseRecord{
+			ID:             xid.New().String(),
+			ConversationID: item.ConversationID,
+			Name:           item.Name,
+			Quantity:       item.Quantity,
+			Unit:           item.Unit,
+			Category:       item.Category,
+			Event:          event,
+			At:             t.Unix(),
+		})
+	}
+	return records
+}
+
+type PurchaseRecords []*PurchaseRecord
+
+// Suggestion is a usual item which is not on the shopping list.
+type Suggestion struct {
+	// Item is a template of the item to add, it has the quantity of the last purchase.
+	Item *ShoppingItem
+	// Count is the number of purchases.
+	Count int
+	// Due reports whether the average repurchase interval has passed since the last purchase.
+	Due bool
+
+	last     int64
+	interval int64
+}
+
+type Suggestions []*Suggestion
+
+// Suggest returns the usual items which are not in the items,
+// the items which are due to be bought again go first and the others are ordered by frequency.
+func (l PurchaseRecords) Suggest(items ShoppingItems, now time.Time, limit int) Suggestions {
+	records := make(PurchaseRecords, 0, len(l))
+	for _, r := range l {
+		if r.Event == PurchaseEventTypeAdded {
+			records = append(records, r)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].At < records[j].At })
+
+	type stat struct {
+		latest *PurchaseRecord
+		times  []int64
+	}
+	stats := make(map[string]*stat)
+	keys := make([]string, 0)
+	for _, r := range records {
+		key := normalizeItemName(r.Name)
+		s, ok := stats[key]
+		if !ok {
+			s = new(stat)
+			stats[key] = s
+			keys = append(keys, key)
+		}
+		s.latest = r
+		if n := len(s.times); n > 0 && r.At-s.times[n-1] < int64(purchaseMergeWindow/time.Second) {
+			continue
+		}
+		s.times = append(s.times, r.At)
+	}
+
+	onList := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		onList[normalizeItemName(item.Name)] = struct{}{}
+	}
+
+	suggestions := make(Suggestions, 0)
+	for _, key := range keys {
+		s := stats[key]
+		if _, ok := onList[key]; ok || len(s.times) < minSuggestionCount {
+			continue
+		}
+		first, last := s.times[0], s.times[len(s.times)-1]
+		interval := (last - first) / int64(len(s.times)-1)
+		suggestions = append(suggestions, &Suggestion{
+			Item: &ShoppingItem{
+				ConversationID: s.latest.ConversationID,
+				Name:           s.latest.Name,
+				Quantity:       s.latest.Quantity,
+				Unit:           s.latest.Unit,
+				Category:       s.latest.Category,
+			},
+			Count:    len(s.times),
+			Due:      now.Unix()-last >= interval,
+			last:     last,
+			interval: interval,
+		})
+	}
+
+	unix := now.Unix()
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		si, sj := suggestions[i], suggestions[j]
+		if si.Due != sj.Due {
+			return si.Due
+		}
+		if si.Due {
+			// the more overdue, the earlier
+			return (unix-si.last)*sj.interval > (unix-sj.last)*si.interval
+		}
+		if si.Count != sj.Count {
+			return si.Count > sj.Count
+		}
+		return si.last > sj.last
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// Print prints the suggestions, due items are marked with the mark translated by tr.
+func (l Suggestions) Print(tr Translator) string {
+	var b strings.Builder
+	for _, s := range l {
+		if s.Due {
+			fmt.Fprintf(&b, "・%s%s\n", s.Item.Label(), tr.translate("（そろそろ）"))
+			continue
+		}
+		fmt.Fprintf(&b, "・%s\n", s.Item.Label())
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurchaseRecords_Suggest(t *testing.T) {
+	t.Parallel()
+	day := int64(24 * 60 * 60)
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ago := func(days int64) int64 { return now.Unix() - days*day }
+	added := func(name string, quantity int, unit string, at int64) *PurchaseRecord {
+		return &PurchaseRecord{Name: name, Quantity: quantity, Unit: unit, Event: PurchaseEventTypeAdded, At: at}
+	}
+
+	records := PurchaseRecords{
+		// weekly, last bought 8 days ago: due
+		added("牛乳", 1, "", ago(22)),
+		added("牛乳", 1, "", ago(15)),
+		added("牛乳", 2, "", ago(8)),
+		// every 10 days, last bought 3 days ago: not due
+		added("卵", 1, "パック", ago(23)),
+		added("卵", 1, "パック", ago(13)),
+		added("卵", 1, "パック", ago(3)),
+		// twice, last bought 1 day ago: not due
+		added("パン", 1, "", ago(11)),
+		added("パン", 1, "", ago(1)),
+		// additions within a day are a purchase
+		added("りんご", 1, "個", ago(20)),
+		added("りんご", 2, "個", ago(20)+60),
+		// on the list
+		added("洗剤", 1, "", ago(40)),
+		added("洗剤", 1, "", ago(20)),
+		{Name: "洗剤", Quantity: 1, Event: PurchaseEventTypeCleared, At: ago(19)},
+	}
+	items := ShoppingItems{{Name: "洗剤", Quantity: 1}}
+
+	got := records.Suggest(items, now, 10)
+	assert.Equal(t, "・牛乳 x2（そろそろ）\n・卵 1パック\n・パン", got.Print(nil))
+	assert.Equal(t, []int{3, 3, 2}, []int{got[0].Count, got[1].Count, got[2].Count})
+
+	got = records.Suggest(items, now, 1)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "牛乳", got[0].Item.Name)
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -25,11 +26,26 @@ type ReminderItem struct {
 	ConversationID ConversationID
 	Scheduler      Scheduler
 	Executor       *Executor
+	// SnoozedFrom is the ID of the original item if the item is created by snooze.
+	SnoozedFrom ReminderItemID
+	// AcknowledgedAt is the last time the reminder was marked as done (UNIX time).
+	AcknowledgedAt int64
+	// Paused reports whether the reminder is temporarily disabled.
+	Paused bool
+	// Exclusion skips some schedules of the Scheduler, it is optional.
+	Exclusion *Exclusion
+	// LastDelivery is the latest finished delivery, it is nil if never executed.
+	LastDelivery *Delivery
+	// Revision is incremented when the schedule of the item is changed,
+	// it makes the task name unique because the queue rejects a deleted task name for a while.
+	Revision int
 }
 
 type ReminderItemIDJSON struct {
 	ConversationID string `json:"conversation_id"`
 	ItemID         string `json:"item_id"`
+	// ScheduledAt is the scheduled time of the task (UNIX time), it is zero in old tasks.
+	ScheduledAt int64 `json:"scheduled_at,omitempty"`
 }
 
 func (r ReminderItem) IDJSON() *ReminderItemIDJSON {
@@ -39,20 +55,97 @@ func (r ReminderItem) IDJSON() *ReminderItemIDJSON {
 	}
 }
 
+// Next returns next scheduled time which is not excluded, ErrEndSchedule or ErrTooManyExcludedSchedules.
+func (r *ReminderItem) Next(t time.Time) (time.Time, error) {
+	for i := 0; i < maxExcludedSchedules; i++ {
+		next, err := r.Scheduler.Next(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !r.Exclusion.Excludes(next) {
+			return next, nil
+		}
+		t = next
+	}
+	return time.Time{}, ErrTooManyExcludedSchedules
+}
+
+// UIText returns a text of the schedule including the exclusion for UI.
+func (r *ReminderItem) UIText() string {
+	if r.Exclusion.Empty() {
+		return r.Scheduler.UIText()
+	}
+	return r.Scheduler.UIText() + " " + r.Exclusion.UIText()
+}
+
+// Ended reports whether the item has no more schedule after t.
+func (r *ReminderItem) Ended(t time.Time) bool {
+	_, err := r.Next(t)
+	return errors.Is(err, ErrEndSchedule)
+}
+
+// RootID returns the ID of the original item which is not created by snooze.
+func (r *ReminderItem) RootID() ReminderItemID {
+	if r.SnoozedFrom != "" {
+		return r.SnoozedFrom
+	}
+	return r.ID
+}
+
+// Snooze returns a new one-shot item which executes the same executor after d from t.
+func (r *ReminderItem) Snooze(t time.Time, d time.Duration) *ReminderItem {
+	executor := *r.Executor
+	item := NewReminderItem(r.ConversationID, &OneshotScheduler{Time: t.Add(d)}, &executor)
+	item.SnoozedFrom = r.RootID()
+	return item
+}
+
 type Executor struct {
 	Type ExecutorType
+	// Payload is an argument of the executor,
+	// e.g. the text of ExecutorTypeMessage or the list id of ExecutorTypeShoppingList.
+	Payload string
+}
+
+// UIText returns a text for UI.
+func (e *Executor) UIText() string {
+	if e.Type == ExecutorTypeMessage && e.Payload != "" {
+		return e.Payload
+	}
+	return e.Type.UIText()
 }
 
 type ExecutorType int
 
 const (
 	ExecutorTypeShoppingList ExecutorType = iota + 1
+	ExecutorTypeMessage
+	ExecutorTypeWeather
 )
 
+var ErrInvalidExecutorType = errors.New("invalid executor type")
+
+func ParseExecutorType(s string) (ExecutorType, error) {
+	switch s {
+	case ExecutorTypeShoppingList.String():
+		return ExecutorTypeShoppingList, nil
+	case ExecutorTypeMessage.String():
+		return ExecutorTypeMessage, nil
+	case ExecutorTypeWeather.String():
+		return ExecutorTypeWeather, nil
+	default:
+		return 0, ErrInvalidExecutorType
+	}
+}
+
 func (t ExecutorType) String() string {
 	switch t {
 	case ExecutorTypeShoppingList:
 		return "shopping_list"
+	case ExecutorTypeMessage:
+		return "message"
+	case ExecutorTypeWeather:
+		return "weather"
 	default:
 		return "unknown"
 	}
@@ -62,6 +155,10 @@ func (t ExecutorType) UIText() string {
 	switch t {
 	case ExecutorTypeShoppingList:
 		return "買い物リスト"
+	case ExecutorTypeMessage:
+		return "メッセージ"
+	case ExecutorTypeWeather:
+		return "天気"
 	default:
 		return "unknown"
 	}
@@ -69,7 +166,8 @@ func (t ExecutorType) UIText() string {
 
 type ReminderItems []*ReminderItem
 
-func (l ReminderItems) Print(typ ListType) string {
+// Print prints the reminders, paused ones are marked with the mark translated by tr.
+func (l ReminderItems) Print(typ ListType, tr Translator) string {
 	var b strings.Builder
 	for i, item := range l {
 		switch typ {
@@ -79,7 +177,14 @@ func (l ReminderItems) Print(typ ListType) string {
 		case ListTypeDotted:
 			fmt.Fprint(&b, "・")
 		}
-		fmt.Fprintf(&b, "%s: %s\n", item.Executor.Type, item.Scheduler)
+		fmt.Fprintf(&b, "%s: %s", item.Executor.Type, item.Scheduler)
+		if !item.Exclusion.Empty() {
+			fmt.Fprint(&b, " "+item.Exclusion.UIText())
+		}
+		if item.Paused {
+			fmt.Fprint(&b, tr.translate("（一時停止中）"))
+		}
+		fmt.Fprint(&b, "\n")
 	}
 	return strings.TrimRight(b.String(), "\n")
 }
@@ -88,10 +193,24 @@ func (l ReminderItems) FilterNextSchedule(t time.Time, d time.Duration) Reminder
 	threshold := t.Add(d)
 	matched := make([]*ReminderItem, 0, len(l))
 	for _, item := range l {
-		next, err := item.Scheduler.Next(t)
+		if item.Paused {
+			continue
+		}
+		next, err := item.Next(t)
 		if err == nil && next.Before(threshold) {
 			matched = append(matched, item)
 		}
 	}
 	return matched
 }
+
+// FilterEnded returns items which have no more schedule after t.
+func (l ReminderItems) FilterEnded(t time.Time) ReminderItems {
+	matched := make([]*ReminderItem, 0, len(l))
+	for _, item := range l {
+		if item.Ended(t) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
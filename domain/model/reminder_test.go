@@ -44,6 +44,30 @@ func TestReminderItems_FilterNextSchedule(t *testing.T) {
 						Time: testTime,
 					},
 				},
+				{
+					Scheduler: &WeeklyScheduler{
+						Time:     testTime.Add(30 * time.Minute),
+						Weekdays: []time.Weekday{time.Thursday},
+					},
+				},
+				{
+					Scheduler: &MonthlyScheduler{
+						Time: testTime.Add(30 * time.Minute),
+						Day:  2,
+					},
+				},
+				{
+					Scheduler: &CronScheduler{
+						Spec:     "0 * * * *",
+						Location: time.UTC,
+					},
+				},
+				{
+					Scheduler: &OneshotScheduler{
+						Time: testTime.Add(time.Minute),
+					},
+					Paused: true,
+				},
 			},
 			d:    time.Hour,
 			want: ReminderItems{},
@@ -61,6 +85,24 @@ func TestReminderItems_FilterNextSchedule(t *testing.T) {
 						Time: testTime.Add(-23*time.Hour - 30*time.Minute),
 					},
 				},
+				{
+					Scheduler: &WeeklyScheduler{
+						Time:     testTime.Add(30 * time.Minute),
+						Weekdays: []time.Weekday{time.Wednesday},
+					},
+				},
+				{
+					Scheduler: &MonthlyScheduler{
+						Time: testTime.Add(30 * time.Minute),
+						Day:  1,
+					},
+				},
+				{
+					Scheduler: &CronScheduler{
+						Spec:     "30 0 * * *",
+						Location: time.UTC,
+					},
+				},
 			},
 			d: time.Hour,
 			want: ReminderItems{
@@ -74,6 +116,24 @@ func TestReminderItems_FilterNextSchedule(t *testing.T) {
 						Time: testTime.Add(-23*time.Hour - 30*time.Minute),
 					},
 				},
+				{
+					Scheduler: &WeeklyScheduler{
+						Time:     testTime.Add(30 * time.Minute),
+						Weekdays: []time.Weekday{time.Wednesday},
+					},
+				},
+				{
+					Scheduler: &MonthlyScheduler{
+						Time: testTime.Add(30 * time.Minute),
+						Day:  1,
+					},
+				},
+				{
+					Scheduler: &CronScheduler{
+						Spec:     "30 0 * * *",
+						Location: time.UTC,
+					},
+				},
 			},
 		},
 	}
@@ -86,3 +146,123 @@ func TestReminderItems_FilterNextSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestReminderItem_Ended(t *testing.T) {
+	t.Parallel()
+	testTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		item *ReminderItem
+		want bool
+	}{
+		{
+			name: "oneshot before the schedule",
+			item: &ReminderItem{
+				Scheduler: &OneshotScheduler{
+					Time: testTime.Add(time.Minute),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "oneshot after the schedule",
+			item: &ReminderItem{
+				Scheduler: &OneshotScheduler{
+					Time: testTime,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "daily",
+			item: &ReminderItem{
+				Scheduler: &DailyScheduler{
+					Time: testTime.Add(-time.Hour),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "too many excluded schedules",
+			item: &ReminderItem{
+				Scheduler: &DailyScheduler{
+					Time: testTime.Add(-time.Hour),
+				},
+				Exclusion: &Exclusion{Ranges: []*DateRange{{
+					Start: testTime,
+					End:   testTime.AddDate(50, 0, 0),
+				}}},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.item.Ended(testTime)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExecutorType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s       string
+		want    ExecutorType
+		wantErr error
+	}{
+		{s: "shopping_list", want: ExecutorTypeShoppingList},
+		{s: "message", want: ExecutorTypeMessage},
+		{s: "weather", want: ExecutorTypeWeather},
+		{s: "unknown", want: 0, wantErr: ErrInvalidExecutorType},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseExecutorType(tt.s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReminderItem_Snooze(t *testing.T) {
+	t.Parallel()
+	testTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	executor := &Executor{Type: ExecutorTypeMessage, Payload: "test"}
+	root := &ReminderItem{
+		ID:             "root",
+		ConversationID: "c1",
+		Scheduler:      &DailyScheduler{Time: testTime},
+		Executor:       executor,
+	}
+
+	snoozed := root.Snooze(testTime, 10*time.Minute)
+	assert.NotEqual(t, root.ID, snoozed.ID)
+	assert.Equal(t, root.ConversationID, snoozed.ConversationID)
+	assert.Equal(t, ReminderItemID("root"), snoozed.SnoozedFrom)
+	assert.Equal(t, &OneshotScheduler{Time: testTime.Add(10 * time.Minute)}, snoozed.Scheduler)
+	assert.Equal(t, executor, snoozed.Executor)
+	assert.NotSame(t, executor, snoozed.Executor)
+
+	// snooze of a snoozed item refers to the original item
+	again := snoozed.Snooze(testTime, time.Hour)
+	assert.Equal(t, ReminderItemID("root"), again.SnoozedFrom)
+	assert.Equal(t, ReminderItemID("root"), again.RootID())
+	assert.Equal(t, ReminderItemID("root"), root.RootID())
+}
+
+func TestReminderItems_FilterEnded(t *testing.T) {
+	t.Parallel()
+	testTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	items := ReminderItems{
+		{ID: "ended", Scheduler: &OneshotScheduler{Time: testTime.Add(-time.Minute)}},
+		{ID: "pending", Scheduler: &OneshotScheduler{Time: testTime.Add(time.Minute)}},
+		{ID: "daily", Scheduler: &DailyScheduler{Time: testTime.Add(-time.Hour)}},
+	}
+	got := items.FilterEnded(testTime)
+	assert.Equal(t, items[0:1], got)
+}
@@ -2,6 +2,9 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 var (
 	ErrEndSchedule          = errors.New("end of schedule")
 	ErrInvalidSchedulerType = errors.New("invalid scheduler type")
+	ErrInvalidSchedule      = errors.New("invalid schedule")
 )
 
 type Scheduler interface {
@@ -37,10 +41,50 @@ func ParseScheduler(serialized string) (Scheduler, error) {
 			return nil, err
 		}
 		return s, nil
+	case schedulerTypeWeekly:
+		s := new(WeeklyScheduler)
+		if err := s.parse(serialized); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case schedulerTypeMonthly:
+		s := new(MonthlyScheduler)
+		if err := s.parse(serialized); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case schedulerTypeCron:
+		s := new(CronScheduler)
+		if err := s.parse(serialized); err != nil {
+			return nil, err
+		}
+		return s, nil
 	}
 	return nil, ErrInvalidSchedulerType
 }
 
+// WithClock returns a copy of s which runs at hour:minute in the location of s.
+// CronScheduler is not supported because it has no single time of day.
+func WithClock(s Scheduler, hour, minute int) (Scheduler, error) {
+	clock := func(t time.Time) time.Time {
+		year, month, day := t.Date()
+		return time.Date(year, month, day, hour, minute, 0, 0, t.Location())
+	}
+
+	switch s := s.(type) {
+	case *OneshotScheduler:
+		return &OneshotScheduler{Time: clock(s.Time)}, nil
+	case *DailyScheduler:
+		return &DailyScheduler{Time: clock(s.Time)}, nil
+	case *WeeklyScheduler:
+		return &WeeklyScheduler{Time: clock(s.Time), Weekdays: s.Weekdays}, nil
+	case *MonthlyScheduler:
+		return &MonthlyScheduler{Time: clock(s.Time), Day: s.Day}, nil
+	default:
+		return nil, xerrors.Errorf("failed to change clock of %T: %w", s, ErrInvalidSchedule)
+	}
+}
+
 type schedulerType string
 
 func (t schedulerType) String() string {
@@ -50,6 +94,9 @@ func (t schedulerType) String() string {
 const (
 	schedulerTypeOneshot schedulerType = "o"
 	schedulerTypeDaily   schedulerType = "d"
+	schedulerTypeWeekly  schedulerType = "w"
+	schedulerTypeMonthly schedulerType = "m"
+	schedulerTypeCron    schedulerType = "c"
 
 	schedulerSep         = "#"
 	schedulerElementSize = 2
@@ -61,9 +108,9 @@ type OneshotScheduler struct {
 
 func (s *OneshotScheduler) parse(serialized string) error {
 	st := strings.TrimPrefix(serialized, schedulerTypeOneshot.String()+schedulerSep)
-	t, err := time.Parse(time.RFC3339, st)
+	t, err := parseTime(st)
 	if err != nil {
-		return xerrors.Errorf("failed to parse time: %w", err)
+		return err
 	}
 
 	s.Time = t
@@ -71,7 +118,7 @@ func (s *OneshotScheduler) parse(serialized string) error {
 }
 
 func (s *OneshotScheduler) String() string {
-	return schedulerTypeOneshot.String() + schedulerSep + s.Time.Format(time.RFC3339)
+	return schedulerTypeOneshot.String() + schedulerSep + formatLocalTime(s.Time)
 }
 
 func (s *OneshotScheduler) UIText() string {
@@ -91,9 +138,9 @@ type DailyScheduler struct {
 
 func (s *DailyScheduler) parse(serialized string) error {
 	st := strings.TrimPrefix(serialized, schedulerTypeDaily.String()+schedulerSep)
-	t, err := time.Parse(time.RFC3339, st)
+	t, err := parseTime(st)
 	if err != nil {
-		return xerrors.Errorf("failed to parse time: %w", err)
+		return err
 	}
 
 	s.Time = t
@@ -101,7 +148,7 @@ func (s *DailyScheduler) parse(serialized string) error {
 }
 
 func (s *DailyScheduler) String() string {
-	return schedulerTypeDaily.String() + schedulerSep + s.Time.Format(time.RFC3339)
+	return schedulerTypeDaily.String() + schedulerSep + formatLocalTime(s.Time)
 }
 
 func (s *DailyScheduler) UIText() string {
@@ -118,3 +165,263 @@ func (s *DailyScheduler) Next(t time.Time) (time.Time, error) {
 	}
 	return target.AddDate(0, 0, 1), nil
 }
+
+// WeeklyScheduler fires at the clock of Time on every weekday in Weekdays.
+type WeeklyScheduler struct {
+	Time     time.Time
+	Weekdays []time.Weekday
+}
+
+func (s *WeeklyScheduler) parse(serialized string) error {
+	st := strings.TrimPrefix(serialized, schedulerTypeWeekly.String()+schedulerSep)
+	c := strings.SplitN(st, schedulerSep, weeklySchedulerElementSize)
+	if len(c) < weeklySchedulerElementSize {
+		return xerrors.Errorf("invalid weekly schedule %q: %w", serialized, ErrInvalidSchedule)
+	}
+
+	weekdays, err := parseWeekdays(c[0])
+	if err != nil {
+		return err
+	}
+	t, err := parseLocalTime(c[1], c[2])
+	if err != nil {
+		return err
+	}
+
+	s.Time = t
+	s.Weekdays = weekdays
+	return nil
+}
+
+func (s *WeeklyScheduler) String() string {
+	return schedulerTypeWeekly.String() + schedulerSep + formatWeekdays(s.Weekdays) +
+		schedulerSep + formatLocalTime(s.Time)
+}
+
+func (s *WeeklyScheduler) UIText() string {
+	names := make([]string, 0, len(s.Weekdays))
+	for _, wd := range sortWeekdays(s.Weekdays) {
+		names = append(names, wd.String()[:3])
+	}
+	return s.Time.Format("at 15:04 every ") + strings.Join(names, ", ") + "."
+}
+
+func (s *WeeklyScheduler) Next(t time.Time) (time.Time, error) {
+	if len(s.Weekdays) == 0 {
+		return time.Time{}, ErrEndSchedule
+	}
+
+	weekdays := make(map[time.Weekday]struct{}, len(s.Weekdays))
+	for _, wd := range s.Weekdays {
+		weekdays[wd] = struct{}{}
+	}
+
+	loc := s.Time.Location()
+	year, month, day := t.In(loc).Date()
+	hour, minute, sec := s.Time.Clock()
+	// a week and a day covers the case that the only weekday is today and already passed
+	for i := 0; i <= daysPerWeek; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, time.UTC)
+		if _, ok := weekdays[date.Weekday()]; !ok {
+			continue
+		}
+		target := time.Date(year, month, day+i, hour, minute, sec, 0, loc)
+		if t.Before(target) {
+			return target, nil
+		}
+	}
+	return time.Time{}, ErrEndSchedule
+}
+
+// MonthlyScheduler fires at the clock of Time on Day of every month.
+// If the month is shorter than Day, it fires on the last day of the month.
+type MonthlyScheduler struct {
+	Time time.Time
+	Day  int
+}
+
+func (s *MonthlyScheduler) parse(serialized string) error {
+	st := strings.TrimPrefix(serialized, schedulerTypeMonthly.String()+schedulerSep)
+	c := strings.SplitN(st, schedulerSep, monthlySchedulerElementSize)
+	if len(c) < monthlySchedulerElementSize {
+		return xerrors.Errorf("invalid monthly schedule %q: %w", serialized, ErrInvalidSchedule)
+	}
+
+	day, err := strconv.Atoi(c[0])
+	if err != nil {
+		return xerrors.Errorf("failed to parse day: %w", err)
+	}
+	if day < 1 || day > maxDaysPerMonth {
+		return xerrors.Errorf("invalid day %d: %w", day, ErrInvalidSchedule)
+	}
+	t, err := parseLocalTime(c[1], c[2])
+	if err != nil {
+		return err
+	}
+
+	s.Time = t
+	s.Day = day
+	return nil
+}
+
+func (s *MonthlyScheduler) String() string {
+	return schedulerTypeMonthly.String() + schedulerSep + strconv.Itoa(s.Day) +
+		schedulerSep + formatLocalTime(s.Time)
+}
+
+func (s *MonthlyScheduler) UIText() string {
+	return s.Time.Format("at 15:04 on day ") + strconv.Itoa(s.Day) + " of every month."
+}
+
+func (s *MonthlyScheduler) Next(t time.Time) (time.Time, error) {
+	if s.Day < 1 || s.Day > maxDaysPerMonth {
+		return time.Time{}, ErrEndSchedule
+	}
+
+	loc := s.Time.Location()
+	year, month, _ := t.In(loc).Date()
+	hour, minute, sec := s.Time.Clock()
+	for i := 0; i < 2; i++ {
+		m := month + time.Month(i)
+		day := min(s.Day, daysIn(year, m))
+		target := time.Date(year, m, day, hour, minute, sec, 0, loc)
+		if t.Before(target) {
+			return target, nil
+		}
+	}
+	return time.Time{}, ErrEndSchedule
+}
+
+// CronScheduler fires on the schedule of a standard 5 fields cron expression
+// (minute, hour, day of month, month and day of week) evaluated in Location.
+type CronScheduler struct {
+	Spec     string
+	Location *time.Location
+}
+
+func (s *CronScheduler) parse(serialized string) error {
+	st := strings.TrimPrefix(serialized, schedulerTypeCron.String()+schedulerSep)
+	c := strings.SplitN(st, schedulerSep, cronSchedulerElementSize)
+	if len(c) < cronSchedulerElementSize {
+		return xerrors.Errorf("invalid cron schedule %q: %w", serialized, ErrInvalidSchedule)
+	}
+
+	loc, err := time.LoadLocation(c[0])
+	if err != nil {
+		return xerrors.Errorf("failed to load location: %w", err)
+	}
+	if _, err := parseCronSpec(c[1]); err != nil {
+		return err
+	}
+
+	s.Spec = c[1]
+	s.Location = loc
+	return nil
+}
+
+func (s *CronScheduler) String() string {
+	return schedulerTypeCron.String() + schedulerSep + s.location().String() + schedulerSep + s.Spec
+}
+
+func (s *CronScheduler) UIText() string {
+	return fmt.Sprintf("by cron %q (%s).", s.Spec, s.location())
+}
+
+func (s *CronScheduler) Next(t time.Time) (time.Time, error) {
+	spec, err := parseCronSpec(s.Spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return spec.next(t, s.location())
+}
+
+func (s *CronScheduler) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+const (
+	weeklySchedulerElementSize  = 3
+	monthlySchedulerElementSize = 3
+	cronSchedulerElementSize    = 2
+	daysPerWeek                 = 7
+	maxDaysPerMonth             = 31
+	weekdaySep                  = ","
+)
+
+// formatLocalTime serializes t with its location name so that the wall clock
+// can be restored in the same location including DST transitions.
+func formatLocalTime(t time.Time) string {
+	return t.Location().String() + schedulerSep + t.Format(time.RFC3339)
+}
+
+func parseLocalTime(name, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("failed to parse time: %w", err)
+	}
+	// fallback to the fixed offset if the location is unknown
+	if name == "" || name == "Local" {
+		return t, nil
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		t = t.In(loc)
+	}
+	return t, nil
+}
+
+// parseTime parses both of "{location}#{RFC3339}" and legacy "{RFC3339}".
+func parseTime(value string) (time.Time, error) {
+	if name, v, ok := strings.Cut(value, schedulerSep); ok {
+		return parseLocalTime(name, v)
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("failed to parse time: %w", err)
+	}
+	return t, nil
+}
+
+func formatWeekdays(weekdays []time.Weekday) string {
+	s := make([]string, 0, len(weekdays))
+	for _, wd := range sortWeekdays(weekdays) {
+		s = append(s, strconv.Itoa(int(wd)))
+	}
+	return strings.Join(s, weekdaySep)
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	if value == "" {
+		return nil, xerrors.Errorf("empty weekdays: %w", ErrInvalidSchedule)
+	}
+	c := strings.Split(value, weekdaySep)
+	weekdays := make([]time.Weekday, 0, len(c))
+	for _, v := range c {
+		wd, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to parse weekday: %w", err)
+		}
+		if wd < int(time.Sunday) || wd > int(time.Saturday) {
+			return nil, xerrors.Errorf("invalid weekday %d: %w", wd, ErrInvalidSchedule)
+		}
+		weekdays = append(weekdays, time.Weekday(wd))
+	}
+	return sortWeekdays(weekdays), nil
+}
+
+// sortWeekdays returns sorted and deduplicated weekdays.
+func sortWeekdays(weekdays []time.Weekday) []time.Weekday {
+	ret := make([]time.Weekday, 0, len(weekdays))
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if slices.Contains(weekdays, wd) {
+			ret = append(ret, wd)
+		}
+	}
+	return ret
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
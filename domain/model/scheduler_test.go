@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOneshotScheduler_Next(t *testing.T) {
@@ -47,6 +48,8 @@ func TestDailyScheduler_Next(t *testing.T) {
 	t.Parallel()
 
 	testLoc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
 	tests := []struct {
 		scheduler *DailyScheduler
 		now       time.Time
@@ -73,6 +76,12 @@ func TestDailyScheduler_Next(t *testing.T) {
 			now:  time.Date(2021, 4, 1, 4, 0, 0, 0, testLoc).In(time.UTC),
 			want: time.Date(2021, 4, 2, 3, 15, 30, 0, testLoc),
 		},
+		{
+			// keeps the wall clock across DST after a round trip of the serialization
+			scheduler: mustParseDailyScheduler(t, "d#America/New_York#2021-01-01T09:00:00-05:00"),
+			now:       time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2021, 7, 1, 9, 0, 0, 0, newYork),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -80,7 +89,269 @@ func TestDailyScheduler_Next(t *testing.T) {
 			t.Parallel()
 			got, err := tt.scheduler.Next(tt.now)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.Equal(t, tt.want.Location().String(), got.Location().String())
+		})
+	}
+}
+
+func mustParseDailyScheduler(t *testing.T, serialized string) *DailyScheduler {
+	t.Helper()
+	s, err := ParseScheduler(serialized)
+	require.NoError(t, err)
+	daily, ok := s.(*DailyScheduler)
+	require.True(t, ok)
+	return daily
+}
+
+func TestWeeklyScheduler_Next(t *testing.T) {
+	t.Parallel()
+
+	testLoc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		scheduler *WeeklyScheduler
+		now       time.Time
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name: "later today",
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC),
+				Weekdays: []time.Weekday{time.Thursday},
+			},
+			// Thursday
+			now:  time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next weekday in the set",
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC),
+				Weekdays: []time.Weekday{time.Tuesday, time.Friday},
+			},
+			now:  time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 2, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "same weekday next week",
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC),
+				Weekdays: []time.Weekday{time.Thursday},
+			},
+			now:  time.Date(2021, 4, 1, 18, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 8, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekday is decided in the scheduler location",
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2000, 1, 1, 8, 0, 0, 0, testLoc),
+				Weekdays: []time.Weekday{time.Friday},
+			},
+			// Thursday in UTC, Friday in Asia/Tokyo
+			now:  time.Date(2021, 4, 1, 22, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 2, 8, 0, 0, 0, testLoc),
+		},
+		{
+			name: "keep the wall clock across DST",
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+				Weekdays: []time.Weekday{time.Monday},
+			},
+			// Saturday before DST starts on 2021-03-14
+			now:  time.Date(2021, 3, 13, 12, 0, 0, 0, newYork),
+			want: time.Date(2021, 3, 15, 9, 0, 0, 0, newYork),
+		},
+		{
+			name: "empty weekdays",
+			scheduler: &WeeklyScheduler{
+				Time: time.Date(2000, 1, 1, 18, 0, 0, 0, time.UTC),
+			},
+			now:     time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC),
+			want:    time.Time{},
+			wantErr: ErrEndSchedule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.scheduler.Next(tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, tt.want.Equal(got), "got: %s, want: %s", got, tt.want)
+		})
+	}
+}
+
+func TestMonthlyScheduler_Next(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		scheduler *MonthlyScheduler
+		now       time.Time
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name: "this month",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  25,
+			},
+			now:  time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 25, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next month",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  25,
+			},
+			now:  time.Date(2021, 4, 25, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 5, 25, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "end of the year",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  1,
+			},
+			now:  time.Date(2021, 12, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "clamp to the end of a short month",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  31,
+			},
+			now:  time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "clamp to the end of February in a leap year",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  30,
+			},
+			now:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "keep the wall clock across DST",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+				Day:  20,
+			},
+			now:  time.Date(2021, 2, 21, 0, 0, 0, 0, newYork),
+			want: time.Date(2021, 3, 20, 9, 0, 0, 0, newYork),
+		},
+		{
+			name: "invalid day",
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+				Day:  0,
+			},
+			now:     time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:    time.Time{},
+			wantErr: ErrEndSchedule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.scheduler.Next(tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, tt.want.Equal(got), "got: %s, want: %s", got, tt.want)
+		})
+	}
+}
+
+func TestCronScheduler_Next(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		scheduler *CronScheduler
+		now       time.Time
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name:      "every 15 minutes",
+			scheduler: &CronScheduler{Spec: "*/15 * * * *", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 10, 7, 30, 0, time.UTC),
+			want:      time.Date(2021, 4, 1, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekdays only",
+			scheduler: &CronScheduler{Spec: "0 9 * * 1-5", Location: time.UTC},
+			// Friday after 9:00
+			now:  time.Date(2021, 4, 2, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 5, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "7 is Sunday",
+			scheduler: &CronScheduler{Spec: "30 8 * * 7", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2021, 4, 4, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "day of month or day of week",
+			scheduler: &CronScheduler{Spec: "0 0 15 * 1", Location: time.UTC},
+			// Monday 4/5 comes before 4/15
+			now:  time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "list of months",
+			scheduler: &CronScheduler{Spec: "0 12 1 1,7 *", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "leap day",
+			scheduler: &CronScheduler{Spec: "0 0 29 2 *", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "skip the wall clock missing by DST",
+			scheduler: &CronScheduler{Spec: "30 2 * * *", Location: newYork},
+			now:       time.Date(2021, 3, 13, 12, 0, 0, 0, newYork),
+			want:      time.Date(2021, 3, 15, 2, 30, 0, 0, newYork),
+		},
+		{
+			name:      "never",
+			scheduler: &CronScheduler{Spec: "0 0 30 2 *", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Time{},
+			wantErr:   ErrEndSchedule,
+		},
+		{
+			name:      "invalid spec",
+			scheduler: &CronScheduler{Spec: "0 24 * * *", Location: time.UTC},
+			now:       time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Time{},
+			wantErr:   ErrInvalidSchedule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.scheduler.Next(tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, tt.want.Equal(got), "got: %s, want: %s", got, tt.want)
 		})
 	}
 }
@@ -109,6 +380,39 @@ func TestParseScheduler(t *testing.T) {
 				Time: time.Date(2021, 1, 1, 15, 0, 0, 0, time.UTC),
 			},
 		},
+		{
+			serialized: "w#2,5#UTC#2021-01-01T15:00:00Z",
+			want: &WeeklyScheduler{
+				Time:     time.Date(2021, 1, 1, 15, 0, 0, 0, time.UTC),
+				Weekdays: []time.Weekday{time.Tuesday, time.Friday},
+			},
+		},
+		{
+			serialized: "w#7#UTC#2021-01-01T15:00:00Z",
+			wantErr:    ErrInvalidSchedule,
+		},
+		{
+			serialized: "m#25#UTC#2021-01-01T15:00:00Z",
+			want: &MonthlyScheduler{
+				Time: time.Date(2021, 1, 1, 15, 0, 0, 0, time.UTC),
+				Day:  25,
+			},
+		},
+		{
+			serialized: "m#32#UTC#2021-01-01T15:00:00Z",
+			wantErr:    ErrInvalidSchedule,
+		},
+		{
+			serialized: "c#UTC#0 9 * * 1-5",
+			want: &CronScheduler{
+				Spec:     "0 9 * * 1-5",
+				Location: time.UTC,
+			},
+		},
+		{
+			serialized: "c#UTC#0 9 * *",
+			wantErr:    ErrInvalidSchedule,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -120,3 +424,108 @@ func TestParseScheduler(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduler_String(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		scheduler Scheduler
+		want      string
+	}{
+		{
+			scheduler: &OneshotScheduler{
+				Time: time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+			},
+			want: "o#America/New_York#2021-01-01T09:00:00-05:00",
+		},
+		{
+			scheduler: &DailyScheduler{
+				Time: time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+			},
+			want: "d#America/New_York#2021-01-01T09:00:00-05:00",
+		},
+		{
+			scheduler: &WeeklyScheduler{
+				Time:     time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+				Weekdays: []time.Weekday{time.Friday, time.Tuesday, time.Friday},
+			},
+			want: "w#2,5#America/New_York#2021-01-01T09:00:00-05:00",
+		},
+		{
+			scheduler: &MonthlyScheduler{
+				Time: time.Date(2021, 1, 1, 9, 0, 0, 0, newYork),
+				Day:  25,
+			},
+			want: "m#25#America/New_York#2021-01-01T09:00:00-05:00",
+		},
+		{
+			scheduler: &CronScheduler{
+				Spec:     "0 9 * * 1-5",
+				Location: newYork,
+			},
+			want: "c#America/New_York#0 9 * * 1-5",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			got := tt.scheduler.String()
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := ParseScheduler(got)
+			require.NoError(t, err)
+			assert.Equal(t, got, parsed.String())
+		})
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	base := time.Date(2021, 4, 7, 9, 0, 0, 0, tokyo)
+	want := time.Date(2021, 4, 7, 18, 30, 0, 0, tokyo)
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		want      Scheduler
+		wantErr   error
+	}{
+		{
+			name:      "oneshot",
+			scheduler: &OneshotScheduler{Time: base},
+			want:      &OneshotScheduler{Time: want},
+		},
+		{
+			name:      "daily",
+			scheduler: &DailyScheduler{Time: base},
+			want:      &DailyScheduler{Time: want},
+		},
+		{
+			name:      "weekly",
+			scheduler: &WeeklyScheduler{Time: base, Weekdays: []time.Weekday{time.Monday}},
+			want:      &WeeklyScheduler{Time: want, Weekdays: []time.Weekday{time.Monday}},
+		},
+		{
+			name:      "monthly",
+			scheduler: &MonthlyScheduler{Time: base, Day: 25},
+			want:      &MonthlyScheduler{Time: want, Day: 25},
+		},
+		{
+			name:      "cron",
+			scheduler: &CronScheduler{Spec: "0 9 * * *", Location: tokyo},
+			wantErr:   ErrInvalidSchedule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := WithClock(tt.scheduler, 18, 30)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
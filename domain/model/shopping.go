@@ -3,22 +3,122 @@ package model
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/rs/xid"
+	"golang.org/x/text/unicode/norm"
 	"golang.org/x/xerrors"
 )
 
 var (
 	errShoppingItemValidationFailed = errors.New("shopping item validation failed")
+	ErrShoppingListNotFound         = errors.New("shopping list not found")
 )
 
-func NewShoppingItem(conversationID ConversationID, name string, quantity, order int, createdAt time.Time) *ShoppingItem {
+// ShoppingListID identifies a shopping list in a conversation.
+type ShoppingListID string
+
+// DefaultShoppingListID is the list which every conversation has.
+const DefaultShoppingListID ShoppingListID = ""
+
+const defaultShoppingListName = "メイン"
+
+// CategoryOther is the category of items which are not categorized.
+const CategoryOther = "その他"
+
+// AisleOrder is the order of item categories in a store.
+type AisleOrder []string
+
+// DefaultAisleOrder is the order of the categories of the embedded dictionary.
+//
+//nolint:gochecknoglobals
+var DefaultAisleOrder = AisleOrder{
+	"野菜・果物",
+	"肉・魚",
+	"乳製品・卵",
+	"パン・米・麺",
+	"調味料",
+	"冷凍食品",
+	"お菓子",
+	"飲料",
+	"日用品",
+}
+
+// rank returns the position of the category, unknown categories go after the known ones.
+func (o AisleOrder) rank(category string) int {
+	if category == "" || category == CategoryOther {
+		return len(o) + 1
+	}
+	for i, c := range o {
+		if c == category {
+			return i
+		}
+	}
+	return len(o)
+}
+
+type ShoppingList struct {
+	ID             ShoppingListID
+	ConversationID ConversationID
+	Name           string
+	CreatedAt      int64
+}
+
+func NewShoppingList(conversationID ConversationID, name string, createdAt time.Time) *ShoppingList {
+	return &ShoppingList{
+		ID:             ShoppingListID(xid.New().String()),
+		ConversationID: conversationID,
+		Name:           name,
+		CreatedAt:      createdAt.Unix(),
+	}
+}
+
+// DefaultShoppingList returns the default list of the conversation.
+func DefaultShoppingList(conversationID ConversationID) *ShoppingList {
+	return &ShoppingList{
+		ID:             DefaultShoppingListID,
+		ConversationID: conversationID,
+		Name:           defaultShoppingListName,
+	}
+}
+
+func (l *ShoppingList) IsDefault() bool {
+	return l.ID == DefaultShoppingListID
+}
+
+type ShoppingLists []*ShoppingList
+
+// Get returns the list of the id.
+func (l ShoppingLists) Get(id ShoppingListID) (*ShoppingList, error) {
+	for _, list := range l {
+		if list.ID == id {
+			return list, nil
+		}
+	}
+	return nil, xerrors.Errorf("%s: %w", id, ErrShoppingListNotFound)
+}
+
+// FindByName returns the list of the name, the name is compared after normalization.
+func (l ShoppingLists) FindByName(name string) (*ShoppingList, error) {
+	for _, list := range l {
+		if normalizeItemName(list.Name) == normalizeItemName(name) {
+			return list, nil
+		}
+	}
+	return nil, xerrors.Errorf("%s: %w", name, ErrShoppingListNotFound)
+}
+
+func NewShoppingItem(conversationID ConversationID, listID ShoppingListID, name string, quantity int, unit string, order int, createdAt time.Time) *ShoppingItem {
 	return &ShoppingItem{
 		ID:             xid.New().String(),
+		ListID:         listID,
 		Name:           name,
 		Quantity:       quantity,
+		Unit:           unit,
 		ConversationID: conversationID,
 		CreatedAt:      createdAt.Unix(),
 		Order:          order,
@@ -26,12 +126,24 @@ func NewShoppingItem(conversationID ConversationID, name string, quantity, order
 }
 
 type ShoppingItem struct {
-	ID             string
-	Name           string
-	Quantity       int
+	ID       string
+	Name     string
+	Quantity int
+	// Unit is a counter of the quantity such as "パック", it may be empty.
+	Unit string
+	// Category is a section of a store such as "野菜・果物", it is empty if the item is not categorized.
+	Category       string
 	ConversationID ConversationID
+	ListID         ShoppingListID
 	CreatedAt      int64
 	Order          int
+	// CheckedAt is the unix time when the item is purchased, it is 0 if the item is not purchased yet.
+	CheckedAt int64
+}
+
+// Checked reports whether the item is purchased.
+func (m *ShoppingItem) Checked() bool {
+	return m.CheckedAt != 0
 }
 
 func (m *ShoppingItem) Validate() error {
@@ -47,6 +159,34 @@ func (m *ShoppingItem) Validate() error {
 	return nil
 }
 
+// Label returns the name with the quantity, e.g. "卵 2パック" and "牛乳 x3".
+func (m *ShoppingItem) Label() string {
+	switch {
+	case m.Unit != "":
+		return m.Name + " " + strconv.Itoa(m.Quantity) + m.Unit
+	case m.Quantity > 1:
+		return m.Name + " x" + strconv.Itoa(m.Quantity)
+	default:
+		return m.Name
+	}
+}
+
+// SameAs reports whether the quantities of the items can be merged.
+func (m *ShoppingItem) SameAs(item *ShoppingItem) bool {
+	return normalizeItemName(m.Name) == normalizeItemName(item.Name) && m.Unit == item.Unit
+}
+
+func (m *ShoppingItem) category() string {
+	if m.Category == "" {
+		return CategoryOther
+	}
+	return m.Category
+}
+
+func normalizeItemName(name string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(name)))
+}
+
 type ShoppingItems []*ShoppingItem
 
 type ListType int
@@ -56,20 +196,115 @@ const (
 	ListTypeOrdered
 )
 
-func (l ShoppingItems) Print(typ ListType) string {
+// Translator translates a text for UI such as "購入済み" into the language of the conversation,
+// a nil Translator leaves the texts as they are.
+type Translator func(text string) string
+
+func (t Translator) translate(text string) string {
+	if t == nil {
+		return text
+	}
+	return t(text)
+}
+
+// Print prints the items, purchased items are printed in a separate section.
+// Items are grouped by category if any of the items is categorized, the section names are translated by tr.
+// The items should be sorted by Sorted to keep the numbers in order.
+func (l ShoppingItems) Print(typ ListType, tr Translator) string {
 	var b strings.Builder
+	checkedSection := false
+	categorized := l.categorized()
+	category := ""
 	for i, item := range l {
+		switch {
+		case item.Checked():
+			if !checkedSection {
+				checkedSection = true
+				fmt.Fprintf(&b, "[%s]\n", tr.translate("購入済み"))
+			}
+		case categorized && (i == 0 || item.category() != category):
+			category = item.category()
+			fmt.Fprintf(&b, "[%s]\n", tr.translate(category))
+		}
 		switch typ {
 		case ListTypeOrdered:
-			fmt.Fprintf(&b, "%d. %s\n", i+1, item.Name)
+			fmt.Fprintf(&b, "%d. %s\n", i+1, item.Label())
 
 		case ListTypeDotted:
-			fmt.Fprintf(&b, "・%s\n", item.Name)
+			fmt.Fprintf(&b, "・%s\n", item.Label())
 		}
 	}
 	return strings.TrimRight(b.String(), "\n")
 }
 
+// Sorted returns the items in display order,
+// items are ordered by the category in the aisle order and purchased items go last.
+func (l ShoppingItems) Sorted(order AisleOrder) ShoppingItems {
+	ret := make(ShoppingItems, 0, len(l))
+	ret = append(ret, l.Unchecked()...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return order.rank(ret[i].Category) < order.rank(ret[j].Category)
+	})
+	ret = append(ret, l.Checked()...)
+	return ret
+}
+
+func (l ShoppingItems) categorized() bool {
+	for _, item := range l {
+		if item.Category != "" && !item.Checked() {
+			return true
+		}
+	}
+	return false
+}
+
+// Checked returns the purchased items.
+func (l ShoppingItems) Checked() ShoppingItems {
+	ret := make(ShoppingItems, 0, len(l))
+	for _, item := range l {
+		if item.Checked() {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// Unchecked returns the items which are not purchased yet.
+func (l ShoppingItems) Unchecked() ShoppingItems {
+	ret := make(ShoppingItems, 0, len(l))
+	for _, item := range l {
+		if !item.Checked() {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// FindSame returns the item which is the same as the given item, or nil.
+func (l ShoppingItems) FindSame(item *ShoppingItem) *ShoppingItem {
+	for _, v := range l {
+		if v.SameAs(item) {
+			return v
+		}
+	}
+	return nil
+}
+
+// FilterByIDs returns the items of the ids.
+func (l ShoppingItems) FilterByIDs(ids []string) ShoppingItems {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	res := make(ShoppingItems, 0, len(ids))
+	for _, item := range l {
+		if _, ok := set[item.ID]; ok {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
 func (l ShoppingItems) FilterByNames(names []string) ShoppingItems {
 	res := make([]*ShoppingItem, 0)
 	for _, item := range l {
@@ -82,3 +317,64 @@ func (l ShoppingItems) FilterByNames(names []string) ShoppingItems {
 	}
 	return res
 }
+
+// minContainedNameLength is the minimum length of a normalized name to match the items which contain it,
+// a short name such as "す" of "酢" is contained in unrelated names such as "なす".
+const minContainedNameLength = 2
+
+// MatchName returns the items of the name, names are compared after the normalization by normalize.
+// Items of the same name are preferred and exact reports it,
+// the items which contain the name are returned otherwise.
+func (l ShoppingItems) MatchName(name string, normalize func(string) string) (items ShoppingItems, exact bool) {
+	key := normalize(name)
+	if key == "" {
+		return nil, false
+	}
+	var same, contained ShoppingItems
+	for _, item := range l {
+		switch k := normalize(item.Name); {
+		case k == key:
+			same = append(same, item)
+		case strings.Contains(k, key):
+			contained = append(contained, item)
+		}
+	}
+	if len(same) > 0 {
+		return same, true
+	}
+	if utf8.RuneCountInString(key) < minContainedNameLength {
+		return nil, false
+	}
+	return contained, false
+}
+
+// ShoppingUndoWindow is the period in which deleted items can be restored.
+const ShoppingUndoWindow = 10 * time.Minute
+
+var ErrShoppingSnapshotExpired = errors.New("shopping snapshot expired")
+
+type ShoppingSnapshotID string
+
+// ShoppingSnapshot holds deleted items to restore them.
+type ShoppingSnapshot struct {
+	ID             ShoppingSnapshotID
+	ConversationID ConversationID
+	ListID         ShoppingListID
+	Items          ShoppingItems
+	CreatedAt      int64
+}
+
+func NewShoppingSnapshot(conversationID ConversationID, listID ShoppingListID, items ShoppingItems, t time.Time) *ShoppingSnapshot {
+	return &ShoppingSnapshot{
+		ID:             ShoppingSnapshotID(xid.New().String()),
+		ConversationID: conversationID,
+		ListID:         listID,
+		Items:          items,
+		CreatedAt:      t.Unix(),
+	}
+}
+
+// Expired reports whether the undo window has passed at t.
+func (s *ShoppingSnapshot) Expired(t time.Time) bool {
+	return !t.Before(time.Unix(s.CreatedAt, 0).Add(ShoppingUndoWindow))
+}
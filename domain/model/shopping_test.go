@@ -0,0 +1,163 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShoppingItems_Print(t *testing.T) {
+	t.Parallel()
+	items := ShoppingItems{
+		{Name: "卵", Quantity: 2, Unit: "パック"},
+		{Name: "牛乳", Quantity: 3},
+		{Name: "りんご", Quantity: 1},
+		{Name: "豆腐", Quantity: 1, Unit: "丁"},
+	}
+	assert.Equal(t, "1. 卵 2パック\n2. 牛乳 x3\n3. りんご\n4. 豆腐 1丁", items.Print(ListTypeOrdered, nil))
+	assert.Equal(t, "・卵 2パック\n・牛乳 x3\n・りんご\n・豆腐 1丁", items.Print(ListTypeDotted, nil))
+}
+
+func TestShoppingItems_Sorted(t *testing.T) {
+	t.Parallel()
+	items := ShoppingItems{
+		{ID: "1", Name: "卵", Quantity: 1, CheckedAt: 1666416720},
+		{ID: "2", Name: "牛乳", Quantity: 1},
+		{ID: "3", Name: "りんご", Quantity: 3, Unit: "個"},
+	}
+	sorted := items.Sorted(nil)
+	assert.Equal(t, ShoppingItems{items[1], items[2], items[0]}, sorted)
+	assert.Equal(t, "1. 牛乳\n2. りんご 3個\n[購入済み]\n3. 卵", sorted.Print(ListTypeOrdered, nil))
+	// the section names are translated
+	bought := func(string) string { return "Bought" }
+	assert.Equal(t, "1. 牛乳\n2. りんご 3個\n[Bought]\n3. 卵", sorted.Print(ListTypeOrdered, bought))
+	assert.Equal(t, ShoppingItems{items[0]}, items.Checked())
+	assert.Equal(t, ShoppingItems{items[1], items[2]}, items.Unchecked())
+}
+
+func TestShoppingItems_Sorted_Category(t *testing.T) {
+	t.Parallel()
+	items := ShoppingItems{
+		{ID: "1", Name: "洗剤", Quantity: 1, Category: "日用品"},
+		{ID: "2", Name: "電球", Quantity: 1},
+		{ID: "3", Name: "牛乳", Quantity: 1, Category: "乳製品・卵"},
+		{ID: "4", Name: "キャベツ", Quantity: 1, Category: "野菜・果物"},
+		{ID: "5", Name: "卵", Quantity: 1, Category: "乳製品・卵"},
+		{ID: "6", Name: "りんご", Quantity: 1, Category: "野菜・果物", CheckedAt: 1666416720},
+	}
+
+	sorted := items.Sorted(DefaultAisleOrder)
+	assert.Equal(t, ShoppingItems{items[3], items[2], items[4], items[0], items[1], items[5]}, sorted)
+	assert.Equal(t, "[野菜・果物]\n1. キャベツ\n[乳製品・卵]\n2. 牛乳\n3. 卵\n[日用品]\n4. 洗剤\n[その他]\n5. 電球\n[購入済み]\n6. りんご",
+		sorted.Print(ListTypeOrdered, nil))
+
+	// unknown categories go after the categories in the order
+	sorted = items.Sorted(AisleOrder{"日用品", "野菜・果物"})
+	assert.Equal(t, ShoppingItems{items[0], items[3], items[2], items[4], items[1], items[5]}, sorted)
+}
+
+func TestShoppingItems_FindSame(t *testing.T) {
+	t.Parallel()
+	items := ShoppingItems{
+		{ID: "1", Name: "卵", Quantity: 2, Unit: "パック"},
+		{ID: "2", Name: "ｺｰﾗ", Quantity: 1},
+	}
+	tests := []struct {
+		name string
+		item *ShoppingItem
+		want *ShoppingItem
+	}{
+		{name: "same name and unit", item: &ShoppingItem{Name: "卵", Unit: "パック"}, want: items[0]},
+		{name: "different unit", item: &ShoppingItem{Name: "卵", Unit: "個"}, want: nil},
+		{name: "normalized name", item: &ShoppingItem{Name: " コーラ "}, want: items[1]},
+		{name: "not found", item: &ShoppingItem{Name: "牛乳"}, want: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, items.FindSame(tt.item))
+		})
+	}
+}
+
+func TestShoppingItems_MatchName(t *testing.T) {
+	t.Parallel()
+	items := ShoppingItems{
+		{ID: "1", Name: "バター"},
+		{ID: "2", Name: "無塩バター"},
+		{ID: "3", Name: "ﾐﾙｸ"},
+		{ID: "4", Name: "ナス"},
+	}
+	tests := []struct {
+		name      string
+		want      ShoppingItems
+		wantExact bool
+	}{
+		{name: "バター", want: ShoppingItems{items[0]}, wantExact: true},
+		{name: "無塩", want: ShoppingItems{items[1]}},
+		{name: "ばた", want: ShoppingItems{items[0], items[1]}},
+		{name: "みるく", want: ShoppingItems{items[2]}, wantExact: true},
+		{name: "牛乳", want: nil},
+		{name: " ", want: nil},
+		// too short to match a part of the names
+		{name: "す", want: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// a simplified normalization of kana and width
+			normalize := func(s string) string {
+				return strings.Map(func(r rune) rune {
+					if 'ァ' <= r && r <= 'ヶ' {
+						return r - ('ァ' - 'ぁ')
+					}
+					return r
+				}, normalizeItemName(s))
+			}
+			got, exact := items.MatchName(tt.name, normalize)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExact, exact)
+		})
+	}
+}
+
+func TestShoppingLists_FindByName(t *testing.T) {
+	t.Parallel()
+	lists := ShoppingLists{
+		DefaultShoppingList("c1"),
+		{ID: "daily", ConversationID: "c1", Name: "日用品"},
+		{ID: "diy", ConversationID: "c1", Name: "ＤＩＹ"},
+	}
+
+	got, err := lists.FindByName("日用品")
+	assert.NoError(t, err)
+	assert.Equal(t, ShoppingListID("daily"), got.ID)
+
+	got, err = lists.FindByName(" diy ")
+	assert.NoError(t, err)
+	assert.Equal(t, ShoppingListID("diy"), got.ID)
+
+	_, err = lists.FindByName("食料品")
+	assert.ErrorIs(t, err, ErrShoppingListNotFound)
+
+	got, err = lists.Get(DefaultShoppingListID)
+	assert.NoError(t, err)
+	assert.True(t, got.IsDefault())
+
+	_, err = lists.Get("deleted")
+	assert.ErrorIs(t, err, ErrShoppingListNotFound)
+}
+
+func TestShoppingSnapshot_Expired(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	snapshot := NewShoppingSnapshot("c1", DefaultShoppingListID, ShoppingItems{{ID: "1", Name: "卵"}}, now)
+
+	assert.False(t, snapshot.Expired(now))
+	assert.False(t, snapshot.Expired(now.Add(ShoppingUndoWindow-time.Second)))
+	assert.True(t, snapshot.Expired(now.Add(ShoppingUndoWindow)))
+}
@@ -5,6 +5,7 @@ package repository
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/line/line-bot-sdk-go/v7/linebot"
 
@@ -22,13 +23,26 @@ type Handler interface {
 }
 
 type MessageProviderSet interface {
+	// Localize returns the set which labels the quick replies in the language.
+	Localize(model.Language) MessageProviderSet
 	Text(string) MessageProvider
+	// Prompt returns the message which asks for an input of a flow with the quick reply to cancel the flow.
+	Prompt(string) MessageProvider
 	ShoppingDeleteConfirmation(string) MessageProvider
+	ShoppingDeleteChoices(text string, items model.ShoppingItems) MessageProvider
 	ShoppingMenu(string, model.ShoppingReplyType) MessageProvider
-	ReminderMenu(string, model.ReminderReplyType, []*model.ReminderItem) MessageProvider
+	ShoppingUndo(text string, rt model.ShoppingReplyType, snapshotID model.ShoppingSnapshotID) MessageProvider
+	ShoppingLists(text string, lists model.ShoppingLists, current model.ShoppingListID) MessageProvider
+	ShoppingListDeleteConfirmation(text string, listID model.ShoppingListID) MessageProvider
+	ShoppingSuggestions(text string, suggestions model.Suggestions) MessageProvider
+	ReminderMenu(string, model.ReminderReplyType, []*model.ReminderItem, *time.Location) MessageProvider
 	ReminderChoices(string, []string, []model.ExecutorType) MessageProvider
+	ReminderScheduleChoices(text string, executorType model.ExecutorType) MessageProvider
 	TimePicker(text, data string) MessageProvider
+	DateTimePicker(text, data string, minTime time.Time) MessageProvider
 	ReminderDeleteConfirmation(text, data string) MessageProvider
+	ReminderAdded(text, undoData string) MessageProvider
+	ReminderActions(MessageProvider, model.ReminderItemID) MessageProvider
 	Image(originalURL, previewURL string) MessageProvider
 }
 
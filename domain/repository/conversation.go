@@ -11,4 +11,6 @@ import (
 type Conversation interface {
 	SetStatus(context.Context, *model.ConversationStatus) error
 	GetStatus(context.Context, model.ConversationID) (*model.ConversationStatus, error)
+	SetSetting(context.Context, *model.ConversationSetting) error
+	GetSetting(context.Context, model.ConversationID) (*model.ConversationSetting, error)
 }
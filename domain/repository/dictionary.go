@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../../mock/mock_$GOPACKAGE/mock_$GOFILE -package=mock_repository
+
+package repository
+
+import (
+	"context"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+// Dictionary stores the words which conversations teach.
+type Dictionary interface {
+	// AddWord adds the word, it replaces the word of the same surface.
+	AddWord(context.Context, *model.UserWord) error
+	FindWords(context.Context, model.ConversationID) (model.UserWords, error)
+}
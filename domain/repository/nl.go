@@ -2,8 +2,30 @@
 
 package repository
 
-import "github.com/ww24/linebot/domain/model"
+import (
+	"time"
+
+	"github.com/ww24/linebot/domain/model"
+)
 
 type NLParser interface {
 	Parse(string) *model.Item
+	// ParseQuantity splits a line into the item name, the quantity and the unit.
+	ParseQuantity(string) (string, int, string)
+	// Category returns the category of the item name, it returns "" if the category is unknown.
+	Category(string) string
+	// Normalize returns the reading of the name to compare names regardless of kana, kanji and width.
+	Normalize(string) string
+}
+
+// IntentClassifier classifies a text message in the language into an intent.
+type IntentClassifier interface {
+	Classify(model.Language, string) *model.Intent
+	// WithWords returns the classifier which knows the words in addition to the dictionaries.
+	WithWords(model.UserWords) (IntentClassifier, error)
+}
+
+type ScheduleParser interface {
+	ParseSchedule(string, time.Time) (model.Scheduler, string, bool)
+	ParseExclusion(string, time.Time) (*model.Exclusion, string)
 }
@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ww24/linebot/domain/model"
 )
@@ -12,6 +13,15 @@ type Reminder interface {
 	Add(context.Context, *model.ReminderItem) error
 	List(context.Context, model.ConversationID) ([]*model.ReminderItem, error)
 	Get(context.Context, model.ConversationID, model.ReminderItemID) (*model.ReminderItem, error)
+	Update(context.Context, *model.ReminderItem) error
 	Delete(context.Context, model.ConversationID, model.ReminderItemID) error
-	ListAll(context.Context) ([]*model.ReminderItem, error)
+	// ListAll streams all reminder items grouped by conversation to the callback,
+	// it stops and returns the error when the callback returns an error.
+	ListAll(context.Context, func(*model.ReminderItem) error) error
+	Acknowledge(context.Context, model.ConversationID, model.ReminderItemID, time.Time) error
+	// BeginDelivery records the start of the delivery,
+	// it returns code.AlreadyExists if the same slot is already sent or in progress.
+	BeginDelivery(context.Context, *model.Delivery) error
+	// FinishDelivery records the outcome of the delivery and the last delivery of the item.
+	FinishDelivery(context.Context, *model.Delivery) error
 }
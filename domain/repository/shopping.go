@@ -4,13 +4,38 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ww24/linebot/domain/model"
 )
 
 type Shopping interface {
 	Add(context.Context, ...*model.ShoppingItem) error
-	Find(context.Context, model.ConversationID) ([]*model.ShoppingItem, error)
-	BatchDelete(ctx context.Context, conversationID model.ConversationID, ids []string) error
-	DeleteAll(context.Context, model.ConversationID) error
+	Find(context.Context, model.ConversationID, model.ShoppingListID) ([]*model.ShoppingItem, error)
+	// AddQuantity adds the quantity to the item atomically.
+	AddQuantity(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, id string, quantity int) error
+	// Check marks the items as purchased at the time.
+	Check(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string, t time.Time) error
+	// Update updates the name, the quantity, the unit and the category of the item,
+	// it returns code.NotFound if the item does not exist.
+	Update(context.Context, *model.ShoppingItem) error
+	BatchDelete(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) error
+	DeleteAll(context.Context, model.ConversationID, model.ShoppingListID) error
+	AddList(context.Context, *model.ShoppingList) error
+	// FindLists returns the named lists, the default list is not included.
+	FindLists(context.Context, model.ConversationID) ([]*model.ShoppingList, error)
+	UpdateList(context.Context, *model.ShoppingList) error
+	// DeleteList deletes the named list and its items.
+	DeleteList(context.Context, model.ConversationID, model.ShoppingListID) error
+	// AppendHistory appends the records to the purchase history.
+	AppendHistory(context.Context, ...*model.PurchaseRecord) error
+	// FindHistory returns the purchase history since the time in chronological order.
+	FindHistory(ctx context.Context, conversationID model.ConversationID, since time.Time) ([]*model.PurchaseRecord, error)
+	// SaveSnapshot saves the snapshot, the previous snapshot of the conversation is discarded.
+	SaveSnapshot(context.Context, *model.ShoppingSnapshot) error
+	// GetSnapshot returns code.NotFound if the snapshot does not exist.
+	GetSnapshot(context.Context, model.ConversationID, model.ShoppingSnapshotID) (*model.ShoppingSnapshot, error)
+	// RestoreSnapshot restores the items of the snapshot with their ids and deletes the snapshot atomically,
+	// it returns code.NotFound if the snapshot does not exist.
+	RestoreSnapshot(context.Context, model.ConversationID, model.ShoppingSnapshotID) error
 }
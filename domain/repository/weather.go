@@ -14,7 +14,8 @@ type Weather interface {
 
 type WeatherImageStore interface {
 	Save(context.Context, io.Reader, time.Time) (string, error)
-	Get(context.Context, time.Time, time.Duration) (string, error)
+	// Get returns the name of the latest image of the day of the time in the location.
+	Get(context.Context, time.Time, *time.Location, time.Duration) (string, error)
 }
 
 type ImageStore interface {
@@ -2,28 +2,39 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/xerrors"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
 	"github.com/ww24/linebot/internal/code"
+	"github.com/ww24/linebot/internal/config"
 )
 
 type Conversation interface {
 	GetStatus(context.Context, model.ConversationID) (*model.ConversationStatus, error)
 	SetStatus(context.Context, *model.ConversationStatus) error
+	// Setting returns the setting of the conversation, it is empty if the conversation has no setting.
+	Setting(context.Context, model.ConversationID) (*model.ConversationSetting, error)
+	Location(context.Context, model.ConversationID) (*time.Location, error)
+	SetTimezone(context.Context, model.ConversationID, string) (*time.Location, error)
+	Language(context.Context, model.ConversationID) (model.Language, error)
+	SetLanguage(context.Context, model.ConversationID, model.Language) error
 }
 
 type ConversationImpl struct {
 	conversation repository.Conversation
+	loc          *time.Location
 }
 
 func NewConversation(
 	conversation repository.Conversation,
+	ct *config.Time,
 ) *ConversationImpl {
 	return &ConversationImpl{
 		conversation: conversation,
+		loc:          ct.DefaultLocation(),
 	}
 }
 
@@ -31,11 +42,14 @@ func (s *ConversationImpl) GetStatus(ctx context.Context, conversationID model.C
 	ctx, span := tracer.Start(ctx, "Conversation#GetStatus")
 	defer span.End()
 
+	now := time.Now()
 	status, err := s.conversation.GetStatus(ctx, conversationID)
-	if code.From(err) == code.NotFound {
+	// an abandoned flow such as adding shopping items returns to neutral
+	if code.From(err) == code.NotFound || (err == nil && status.Expired(now)) {
 		status = &model.ConversationStatus{
 			ConversationID: conversationID,
 			Type:           model.ConversationStatusTypeNeutral,
+			UpdatedAt:      now.Unix(),
 		}
 		err = s.conversation.SetStatus(ctx, status)
 	}
@@ -49,8 +63,88 @@ func (s *ConversationImpl) SetStatus(ctx context.Context, status *model.Conversa
 	ctx, span := tracer.Start(ctx, "Conversation#SetStatus")
 	defer span.End()
 
+	status.UpdatedAt = time.Now().Unix()
 	if err := s.conversation.SetStatus(ctx, status); err != nil {
 		return xerrors.Errorf("failed to set status: %w", err)
 	}
 	return nil
 }
+
+func (s *ConversationImpl) Setting(ctx context.Context, conversationID model.ConversationID) (*model.ConversationSetting, error) {
+	ctx, span := tracer.Start(ctx, "Conversation#Setting")
+	defer span.End()
+
+	setting, err := s.conversation.GetSetting(ctx, conversationID)
+	if code.From(err) == code.NotFound {
+		return &model.ConversationSetting{ConversationID: conversationID}, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get setting: %w", err)
+	}
+	return setting, nil
+}
+
+// Location returns the time zone of the conversation, the default location is used if it is not set.
+func (s *ConversationImpl) Location(ctx context.Context, conversationID model.ConversationID) (*time.Location, error) {
+	ctx, span := tracer.Start(ctx, "Conversation#Location")
+	defer span.End()
+
+	setting, err := s.Setting(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	return setting.Location(s.loc), nil
+}
+
+func (s *ConversationImpl) SetTimezone(ctx context.Context, conversationID model.ConversationID, name string) (*time.Location, error) {
+	ctx, span := tracer.Start(ctx, "Conversation#SetTimezone")
+	defer span.End()
+
+	loc, err := model.LoadTimezone(name)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load timezone: %w", err)
+	}
+
+	setting, err := s.conversation.GetSetting(ctx, conversationID)
+	if code.From(err) == code.NotFound {
+		setting, err = &model.ConversationSetting{ConversationID: conversationID}, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get setting: %w", err)
+	}
+	setting.Timezone = loc.String()
+	if err := s.conversation.SetSetting(ctx, setting); err != nil {
+		return nil, xerrors.Errorf("failed to set setting: %w", err)
+	}
+	return loc, nil
+}
+
+// Language returns the language of the conversation, Japanese is used if it is not set.
+func (s *ConversationImpl) Language(ctx context.Context, conversationID model.ConversationID) (model.Language, error) {
+	ctx, span := tracer.Start(ctx, "Conversation#Language")
+	defer span.End()
+
+	setting, err := s.Setting(ctx, conversationID)
+	if err != nil {
+		return "", err
+	}
+	return setting.Language.OrDefault(), nil
+}
+
+func (s *ConversationImpl) SetLanguage(ctx context.Context, conversationID model.ConversationID, lang model.Language) error {
+	ctx, span := tracer.Start(ctx, "Conversation#SetLanguage")
+	defer span.End()
+
+	setting, err := s.conversation.GetSetting(ctx, conversationID)
+	if code.From(err) == code.NotFound {
+		setting, err = &model.ConversationSetting{ConversationID: conversationID}, nil
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to get setting: %w", err)
+	}
+	setting.Language = lang
+	if err := s.conversation.SetSetting(ctx, setting); err != nil {
+		return xerrors.Errorf("failed to set setting: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tenntenn/testtime"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/code"
+	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/mock/mock_repository"
+)
+
+func TestConversationImpl_GetStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+	testTime := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	neutral := &model.ConversationStatus{
+		ConversationID: conversationID,
+		Type:           model.ConversationStatusTypeNeutral,
+		UpdatedAt:      testTime.Unix(),
+	}
+	tests := []struct {
+		name  string
+		setup func(*mock_repository.MockConversation)
+		want  *model.ConversationStatus
+	}{
+		{
+			name: "in progress",
+			setup: func(m *mock_repository.MockConversation) {
+				m.EXPECT().GetStatus(gomock.Any(), conversationID).Return(&model.ConversationStatus{
+					ConversationID: conversationID,
+					Type:           model.ConversationStatusTypeShoppingAdd,
+					UpdatedAt:      testTime.Add(-time.Minute).Unix(),
+				}, nil)
+			},
+			want: &model.ConversationStatus{
+				ConversationID: conversationID,
+				Type:           model.ConversationStatusTypeShoppingAdd,
+				UpdatedAt:      testTime.Add(-time.Minute).Unix(),
+			},
+		},
+		{
+			name: "expired",
+			setup: func(m *mock_repository.MockConversation) {
+				m.EXPECT().GetStatus(gomock.Any(), conversationID).Return(&model.ConversationStatus{
+					ConversationID: conversationID,
+					Type:           model.ConversationStatusTypeShoppingAdd,
+					UpdatedAt:      testTime.Add(-24 * time.Hour).Unix(),
+				}, nil)
+				m.EXPECT().SetStatus(gomock.Any(), neutral).Return(nil)
+			},
+			want: neutral,
+		},
+		{
+			name: "not found",
+			setup: func(m *mock_repository.MockConversation) {
+				m.EXPECT().GetStatus(gomock.Any(), conversationID).Return(nil, code.With(errors.New("not found"), code.NotFound))
+				m.EXPECT().SetStatus(gomock.Any(), neutral).Return(nil)
+			},
+			want: neutral,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.True(t, testtime.SetTime(t, testTime))
+
+			ctrl := gomock.NewController(t)
+			m := mock_repository.NewMockConversation(ctrl)
+			tt.setup(m)
+			s := NewConversation(m, &config.Time{})
+			got, err := s.GetStatus(ctx, conversationID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConversationImpl_Setting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	m := mock_repository.NewMockConversation(ctrl)
+	setting := &model.ConversationSetting{ConversationID: conversationID, Timezone: "America/New_York", Language: model.LanguageEnglish}
+	gomock.InOrder(
+		m.EXPECT().GetSetting(gomock.Any(), conversationID).Return(setting, nil),
+		m.EXPECT().GetSetting(gomock.Any(), conversationID).Return(nil, code.With(errors.New("not found"), code.NotFound)),
+	)
+	s := NewConversation(m, &config.Time{})
+
+	got, err := s.Setting(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, setting, got)
+	// a conversation without the setting has the empty one
+	got, err = s.Setting(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, &model.ConversationSetting{ConversationID: conversationID}, got)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/repository"
+)
+
+type Dictionary interface {
+	// Classify classifies the text in the language with the words of the conversation.
+	Classify(ctx context.Context, conversationID model.ConversationID, lang model.Language, text string) (*model.Intent, error)
+	AddWord(ctx context.Context, conversationID model.ConversationID, surface, reading string) (*model.UserWord, error)
+	Words(ctx context.Context, conversationID model.ConversationID) (model.UserWords, error)
+}
+
+// maxCachedClassifiers limits the number of the conversations whose classifier is cached.
+const maxCachedClassifiers = 1000
+
+type DictionaryImpl struct {
+	dictionary repository.Dictionary
+	classifier repository.IntentClassifier
+	mu         sync.Mutex
+	// classifiers caches the classifiers with the words of the conversations, building one loads a tokenizer.
+	classifiers map[model.ConversationID]*wordsClassifier
+}
+
+// wordsClassifier is a classifier with the words, key identifies the words.
+type wordsClassifier struct {
+	key        string
+	classifier repository.IntentClassifier
+}
+
+func NewDictionary(
+	dictionary repository.Dictionary,
+	classifier repository.IntentClassifier,
+) *DictionaryImpl {
+	return &DictionaryImpl{
+		dictionary:  dictionary,
+		classifier:  classifier,
+		mu:          sync.Mutex{},
+		classifiers: make(map[model.ConversationID]*wordsClassifier),
+	}
+}
+
+func (s *DictionaryImpl) Classify(ctx context.Context, conversationID model.ConversationID, lang model.Language, text string) (*model.Intent, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary#Classify")
+	defer span.End()
+
+	// the words are the readings for the Japanese tokenizer
+	if text == "" || lang == model.LanguageEnglish {
+		return s.classifier.Classify(lang, text), nil
+	}
+	words, err := s.dictionary.FindWords(ctx, conversationID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find words: %w", err)
+	}
+	if len(words) == 0 {
+		return s.classifier.Classify(lang, text), nil
+	}
+
+	classifier, err := s.classifierOf(conversationID, words)
+	if err != nil {
+		return nil, err
+	}
+	return classifier.Classify(lang, text), nil
+}
+
+// classifierOf returns the cached classifier of the conversation,
+// it is built again if the words are changed by another instance.
+func (s *DictionaryImpl) classifierOf(conversationID model.ConversationID, words model.UserWords) (repository.IntentClassifier, error) {
+	keys := make([]string, 0, len(words))
+	for _, w := range words {
+		// the surface contains neither "," nor "/"
+		keys = append(keys, w.Surface+"/"+w.Reading)
+	}
+	key := strings.Join(keys, ",")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.classifiers[conversationID]; ok && c.key == key {
+		return c.classifier, nil
+	}
+	classifier, err := s.classifier.WithWords(words)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to apply words: %w", err)
+	}
+	if len(s.classifiers) >= maxCachedClassifiers {
+		s.classifiers = make(map[model.ConversationID]*wordsClassifier)
+	}
+	s.classifiers[conversationID] = &wordsClassifier{key: key, classifier: classifier}
+	return classifier, nil
+}
+
+func (s *DictionaryImpl) AddWord(ctx context.Context, conversationID model.ConversationID, surface, reading string) (*model.UserWord, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary#AddWord")
+	defer span.End()
+
+	word, err := model.NewUserWord(conversationID, surface, reading, time.Now())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create word: %w", err)
+	}
+	// make sure that the tokenizer accepts the word before it is stored
+	if _, err := s.classifier.WithWords(model.UserWords{word}); err != nil {
+		return nil, xerrors.Errorf("failed to apply word: %w", err)
+	}
+	if err := s.dictionary.AddWord(ctx, word); err != nil {
+		return nil, xerrors.Errorf("failed to add word: %w", err)
+	}
+	s.mu.Lock()
+	delete(s.classifiers, conversationID)
+	s.mu.Unlock()
+	return word, nil
+}
+
+func (s *DictionaryImpl) Words(ctx context.Context, conversationID model.ConversationID) (model.UserWords, error) {
+	ctx, span := tracer.Start(ctx, "Dictionary#Words")
+	defer span.End()
+
+	words, err := s.dictionary.FindWords(ctx, conversationID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find words: %w", err)
+	}
+	return words, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/mock/mock_repository"
+)
+
+func TestDictionaryImpl_Classify(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	dictionary := mock_repository.NewMockDictionary(ctrl)
+	words := model.UserWords{{ConversationID: conversationID, Surface: "オイコス", Reading: "オイコス"}}
+	dictionary.EXPECT().FindWords(gomock.Any(), conversationID).Return(words, nil).Times(2)
+	classifier := mock_repository.NewMockIntentClassifier(ctrl)
+	withWords := mock_repository.NewMockIntentClassifier(ctrl)
+	// the classifier with the words is cached
+	classifier.EXPECT().WithWords(words).Return(withWords, nil)
+	want := &model.Intent{Type: model.IntentTypeCheck}
+	withWords.EXPECT().Classify(model.LanguageJapanese, "オイコス買った").Return(want).Times(2)
+	// the words are not needed for events without text and English texts
+	classifier.EXPECT().Classify(model.LanguageJapanese, "").Return(&model.Intent{})
+	classifier.EXPECT().Classify(model.LanguageEnglish, "bought oikos").Return(want)
+
+	s := NewDictionary(dictionary, classifier)
+	got, err := s.Classify(ctx, conversationID, model.LanguageJapanese, "オイコス買った")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	got, err = s.Classify(ctx, conversationID, model.LanguageJapanese, "オイコス買った")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	_, err = s.Classify(ctx, conversationID, model.LanguageJapanese, "")
+	require.NoError(t, err)
+	got, err = s.Classify(ctx, conversationID, model.LanguageEnglish, "bought oikos")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDictionaryImpl_Classify_Changed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	dictionary := mock_repository.NewMockDictionary(ctrl)
+	classifier := mock_repository.NewMockIntentClassifier(ctrl)
+	withWords := mock_repository.NewMockIntentClassifier(ctrl)
+	withWords.EXPECT().Classify(gomock.Any(), gomock.Any()).Return(&model.Intent{}).AnyTimes()
+	oikos := &model.UserWord{ConversationID: conversationID, Surface: "オイコス", Reading: "オイコス"}
+	tofu := &model.UserWord{ConversationID: conversationID, Surface: "絹豆腐", Reading: "キヌドウフ"}
+	s := NewDictionary(dictionary, classifier)
+
+	// the words added by another instance are applied
+	dictionary.EXPECT().FindWords(gomock.Any(), conversationID).Return(model.UserWords{oikos}, nil)
+	classifier.EXPECT().WithWords(model.UserWords{oikos}).Return(withWords, nil)
+	_, err := s.Classify(ctx, conversationID, model.LanguageJapanese, "オイコス")
+	require.NoError(t, err)
+	dictionary.EXPECT().FindWords(gomock.Any(), conversationID).Return(model.UserWords{oikos, tofu}, nil)
+	classifier.EXPECT().WithWords(model.UserWords{oikos, tofu}).Return(withWords, nil)
+	_, err = s.Classify(ctx, conversationID, model.LanguageJapanese, "オイコス")
+	require.NoError(t, err)
+
+	// AddWord drops the cache
+	dictionary.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
+	classifier.EXPECT().WithWords(gomock.Any()).Return(withWords, nil)
+	_, err = s.AddWord(ctx, conversationID, "オイコス", "おいこす")
+	require.NoError(t, err)
+	dictionary.EXPECT().FindWords(gomock.Any(), conversationID).Return(model.UserWords{oikos, tofu}, nil)
+	classifier.EXPECT().WithWords(model.UserWords{oikos, tofu}).Return(withWords, nil)
+	_, err = s.Classify(ctx, conversationID, model.LanguageJapanese, "オイコス")
+	require.NoError(t, err)
+}
+
+func TestDictionaryImpl_AddWord(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	dictionary := mock_repository.NewMockDictionary(ctrl)
+	dictionary.EXPECT().AddWord(gomock.Any(), gomock.Any()).Return(nil)
+	classifier := mock_repository.NewMockIntentClassifier(ctrl)
+	classifier.EXPECT().WithWords(gomock.Any()).Return(classifier, nil)
+
+	s := NewDictionary(dictionary, classifier)
+	got, err := s.AddWord(ctx, conversationID, "オイコス", "おいこす")
+	require.NoError(t, err)
+	assert.Equal(t, "オイコス", got.Reading)
+
+	_, err = s.AddWord(ctx, conversationID, "オイコス", "oikos")
+	require.ErrorIs(t, err, model.ErrInvalidUserWord)
+}
@@ -9,19 +9,26 @@ import (
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/internal/code"
 )
 
 const (
 	syncInterval = 2 * time.Hour
+	// endedItemRetention keeps ended items for a while to allow snooze after delivery.
+	endedItemRetention = 24 * time.Hour
 )
 
 type Reminder interface {
 	Add(context.Context, *model.ReminderItem) error
 	List(context.Context, model.ConversationID) (model.ReminderItems, error)
 	Get(context.Context, model.ConversationID, model.ReminderItemID) (*model.ReminderItem, error)
+	Update(context.Context, *model.ReminderItem) error
 	Delete(context.Context, model.ConversationID, model.ReminderItemID) error
-	ListAll(context.Context) (model.ReminderItems, error)
-	SyncSchedule(context.Context, model.ReminderItems) error
+	SyncSchedule(context.Context) error
+	Snooze(context.Context, model.ConversationID, model.ReminderItemID, time.Duration) (*model.ReminderItem, error)
+	Acknowledge(context.Context, model.ConversationID, model.ReminderItemID) error
+	BeginDelivery(context.Context, *model.Delivery) error
+	FinishDelivery(context.Context, *model.Delivery) error
 }
 
 type ReminderImpl struct {
@@ -80,6 +87,43 @@ func (r *ReminderImpl) Get(ctx context.Context, conversationID model.Conversatio
 	return item, nil
 }
 
+func (r *ReminderImpl) Update(ctx context.Context, item *model.ReminderItem) error {
+	ctx, span := tracer.Start(ctx, "Reminder#Update")
+	defer span.End()
+
+	old, err := r.reminder.Get(ctx, item.ConversationID, item.ID)
+	if err != nil {
+		return xerrors.Errorf("failed to get a reminder item: %w", err)
+	}
+	rescheduled := old.Paused != item.Paused || old.Scheduler.String() != item.Scheduler.String()
+	if rescheduled {
+		// the new revision gives a new task name, re-creating a deleted task name is rejected by the queue for a while
+		item.Revision = old.Revision + 1
+	}
+	if err := r.reminder.Update(ctx, item); err != nil {
+		return xerrors.Errorf("failed to update a reminder item: %w", err)
+	}
+	if !rescheduled {
+		return nil
+	}
+
+	now := time.Now()
+	olds := model.ReminderItems{old}.FilterNextSchedule(now, syncInterval)
+	for _, old := range olds {
+		if err := r.scheduler.Delete(ctx, old.ConversationID, old, now); err != nil {
+			return xerrors.Errorf("failed to delete a schedule: %w", err)
+		}
+	}
+	items := model.ReminderItems{item}.FilterNextSchedule(now, syncInterval)
+	for _, item := range items {
+		if err := r.scheduler.Create(ctx, item.ConversationID, item, now); err != nil {
+			return xerrors.Errorf("failed to create a schedule: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *ReminderImpl) Delete(ctx context.Context, conversationID model.ConversationID, itemID model.ReminderItemID) error {
 	ctx, span := tracer.Start(ctx, "Reminder#Delete")
 	defer span.End()
@@ -103,51 +147,136 @@ func (r *ReminderImpl) Delete(ctx context.Context, conversationID model.Conversa
 	return nil
 }
 
-func (r *ReminderImpl) ListAll(ctx context.Context) (model.ReminderItems, error) {
-	ctx, span := tracer.Start(ctx, "Reminder#ListAll")
+// SyncSchedule deletes ended items and synchronizes the schedules conversation by conversation,
+// only the items of a single conversation are held in memory.
+func (r *ReminderImpl) SyncSchedule(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "Reminder#SyncSchedule")
 	defer span.End()
 
-	items, err := r.reminder.ListAll(ctx)
+	now := time.Now()
+
+	slog.InfoContext(ctx, "service: start to sync schedule")
+
+	var items model.ReminderItems
+	count := 0
+	err := r.reminder.ListAll(ctx, func(item *model.ReminderItem) error {
+		if len(items) > 0 && items[0].ConversationID != item.ConversationID {
+			if err := r.syncConversation(ctx, items, now); err != nil {
+				return err
+			}
+			items = nil
+		}
+		items = append(items, item)
+		count++
+		return nil
+	})
 	if err != nil {
-		return nil, xerrors.Errorf("failed to list all reminder items: %w", err)
+		return xerrors.Errorf("failed to list all reminder items: %w", err)
 	}
-	return items, nil
+	if len(items) > 0 {
+		if err := r.syncConversation(ctx, items, now); err != nil {
+			return err
+		}
+	}
+
+	slog.InfoContext(ctx, "service: finish to sync schedule", slog.Int("count", count))
+
+	return nil
 }
 
-func (r *ReminderImpl) SyncSchedule(ctx context.Context, items model.ReminderItems) error {
-	ctx, span := tracer.Start(ctx, "Reminder#SyncSchedule")
+// syncConversation handles items of a conversation.
+func (r *ReminderImpl) syncConversation(ctx context.Context, items model.ReminderItems, now time.Time) error {
+	if err := r.deleteEnded(ctx, items, now); err != nil {
+		return err
+	}
+
+	items = items.FilterNextSchedule(now, syncInterval)
+	if len(items) == 0 {
+		return nil
+	}
+
+	if err := r.scheduler.Sync(ctx, items[0].ConversationID, items, now); err != nil {
+		return xerrors.Errorf("failed to sync schedule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReminderImpl) Snooze(ctx context.Context, conversationID model.ConversationID, itemID model.ReminderItemID, d time.Duration) (*model.ReminderItem, error) {
+	ctx, span := tracer.Start(ctx, "Reminder#Snooze")
 	defer span.End()
 
-	now := time.Now()
+	item, err := r.reminder.Get(ctx, conversationID, itemID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get a reminder item: %w", err)
+	}
 
-	slog.InfoContext(ctx, "service: start to sync schedule",
-		slog.Any("items", items),
-		slog.Int("count", len(items)),
-	)
+	snoozed := item.Snooze(time.Now(), d)
+	if err := r.Add(ctx, snoozed); err != nil {
+		return nil, err
+	}
 
-	items = items.FilterNextSchedule(now, syncInterval)
+	return snoozed, nil
+}
 
-	slog.InfoContext(ctx, "service: FilterNextSchedule", slog.Int("count", len(items)))
+func (r *ReminderImpl) Acknowledge(ctx context.Context, conversationID model.ConversationID, itemID model.ReminderItemID) error {
+	ctx, span := tracer.Start(ctx, "Reminder#Acknowledge")
+	defer span.End()
 
-	var conversationID model.ConversationID
-	start := 0
-	for i, item := range items {
-		if conversationID == "" {
-			conversationID = item.ConversationID
-		} else if conversationID != item.ConversationID {
-			if err := r.scheduler.Sync(ctx, conversationID, items[start:i], now); err != nil {
-				return xerrors.Errorf("failed to sync schedule: %w", err)
-			}
-			conversationID = item.ConversationID
-			start = i
+	item, err := r.reminder.Get(ctx, conversationID, itemID)
+	if err != nil {
+		return xerrors.Errorf("failed to get a reminder item: %w", err)
+	}
+
+	now := time.Now()
+	rootID := item.RootID()
+	if err := r.reminder.Acknowledge(ctx, conversationID, rootID, now); err != nil && code.From(err) != code.NotFound {
+		return xerrors.Errorf("failed to acknowledge a reminder item: %w", err)
+	}
+
+	items, err := r.reminder.List(ctx, conversationID)
+	if err != nil {
+		return xerrors.Errorf("failed to list reminder items: %w", err)
+	}
+	for _, item := range items {
+		// pending snoozes should not nag any more and the ended root is no longer needed
+		if item.SnoozedFrom != rootID && !(item.ID == rootID && item.Ended(now)) {
+			continue
+		}
+		if err := r.Delete(ctx, conversationID, item.ID); err != nil {
+			return err
 		}
 	}
 
-	if len(items[start:]) > 0 {
-		if err := r.scheduler.Sync(ctx, conversationID, items[start:], now); err != nil {
-			return xerrors.Errorf("failed to sync schedule: %w", err)
+	return nil
+}
+
+func (r *ReminderImpl) deleteEnded(ctx context.Context, items model.ReminderItems, now time.Time) error {
+	for _, item := range items.FilterEnded(now.Add(-endedItemRetention)) {
+		if err := r.reminder.Delete(ctx, item.ConversationID, item.ID); err != nil {
+			return xerrors.Errorf("failed to delete an ended reminder item: %w", err)
 		}
 	}
 
 	return nil
 }
+
+func (r *ReminderImpl) BeginDelivery(ctx context.Context, d *model.Delivery) error {
+	ctx, span := tracer.Start(ctx, "Reminder#BeginDelivery")
+	defer span.End()
+
+	if err := r.reminder.BeginDelivery(ctx, d); err != nil {
+		return xerrors.Errorf("failed to begin delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderImpl) FinishDelivery(ctx context.Context, d *model.Delivery) error {
+	ctx, span := tracer.Start(ctx, "Reminder#FinishDelivery")
+	defer span.End()
+
+	if err := r.reminder.FinishDelivery(ctx, d); err != nil {
+		return xerrors.Errorf("failed to finish delivery: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tenntenn/testtime"
 	"go.uber.org/mock/gomock"
@@ -49,32 +50,48 @@ func TestReminderImpl_SyncSchedule(t *testing.T) {
 			},
 		},
 	}
+	ended := &model.ReminderItem{
+		ConversationID: model.ConversationID("c3"),
+		ID:             model.ReminderItemID("ended"),
+		Scheduler: &model.OneshotScheduler{
+			Time: testTime.Add(-endedItemRetention - time.Minute),
+		},
+	}
 	tests := []struct {
 		name  string
-		setup func(*mock_repository.MockScheduleSynchronizer)
+		setup func(*mock_repository.MockReminder, *mock_repository.MockScheduleSynchronizer)
 		items model.ReminderItems
 	}{
 		{
 			name:  "empty",
-			setup: func(m *mock_repository.MockScheduleSynchronizer) {},
+			setup: func(*mock_repository.MockReminder, *mock_repository.MockScheduleSynchronizer) {},
 			items: model.ReminderItems{},
 		},
 		{
 			name: "one item",
-			setup: func(m *mock_repository.MockScheduleSynchronizer) {
+			setup: func(_ *mock_repository.MockReminder, m *mock_repository.MockScheduleSynchronizer) {
 				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c1"), items[0:1], testTime).Return(nil)
 			},
 			items: items[0:1],
 		},
 		{
 			name: "some items",
-			setup: func(m *mock_repository.MockScheduleSynchronizer) {
+			setup: func(_ *mock_repository.MockReminder, m *mock_repository.MockScheduleSynchronizer) {
 				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c1"), items[0:2], testTime).Return(nil)
 				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c2"), items[2:4], testTime).Return(nil)
 				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c3"), items[4:5], testTime).Return(nil)
 			},
 			items: items[0:5],
 		},
+		{
+			name: "ended item",
+			setup: func(r *mock_repository.MockReminder, m *mock_repository.MockScheduleSynchronizer) {
+				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c2"), items[2:4], testTime).Return(nil)
+				r.EXPECT().Delete(gomock.Any(), ended.ConversationID, ended.ID).Return(nil)
+				m.EXPECT().Sync(gomock.Any(), model.ConversationID("c3"), items[4:5], testTime).Return(nil)
+			},
+			items: model.ReminderItems{items[2], items[3], ended, items[4]},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -83,14 +100,198 @@ func TestReminderImpl_SyncSchedule(t *testing.T) {
 			require.True(t, testtime.SetTime(t, testTime))
 
 			ctrl := gomock.NewController(t)
+			r := mock_repository.NewMockReminder(ctrl)
+			r.EXPECT().ListAll(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, fn func(*model.ReminderItem) error) error {
+					for _, item := range tt.items {
+						if err := fn(item); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			)
 			m := mock_repository.NewMockScheduleSynchronizer(ctrl)
-			tt.setup(m)
+			tt.setup(r, m)
 			service := &ReminderImpl{
+				reminder:  r,
 				scheduler: m,
 			}
 
-			err := service.SyncSchedule(ctx, tt.items)
+			err := service.SyncSchedule(ctx)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReminderImpl_Snooze(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, testtime.SetTime(t, testTime))
+
+	item := &model.ReminderItem{
+		ID:             "root",
+		ConversationID: "c1",
+		Scheduler:      &model.OneshotScheduler{Time: testTime.Add(-time.Minute)},
+		Executor:       &model.Executor{Type: model.ExecutorTypeShoppingList},
+	}
+
+	ctrl := gomock.NewController(t)
+	reminder := mock_repository.NewMockReminder(ctrl)
+	scheduler := mock_repository.NewMockScheduleSynchronizer(ctrl)
+	reminder.EXPECT().Get(gomock.Any(), item.ConversationID, item.ID).Return(item, nil)
+	reminder.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	scheduler.EXPECT().Create(gomock.Any(), item.ConversationID, gomock.Any(), testTime).Return(nil)
+	service := &ReminderImpl{
+		reminder:  reminder,
+		scheduler: scheduler,
+	}
+
+	got, err := service.Snooze(ctx, item.ConversationID, item.ID, 10*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, item.ID, got.SnoozedFrom)
+	require.Equal(t, &model.OneshotScheduler{Time: testTime.Add(10 * time.Minute)}, got.Scheduler)
+}
+
+func TestReminderImpl_Acknowledge(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, testtime.SetTime(t, testTime))
+
+	conversationID := model.ConversationID("c1")
+	root := &model.ReminderItem{
+		ID:             "root",
+		ConversationID: conversationID,
+		Scheduler:      &model.DailyScheduler{Time: testTime.Add(-time.Minute)},
+	}
+	snoozed := &model.ReminderItem{
+		ID:             "snoozed",
+		ConversationID: conversationID,
+		Scheduler:      &model.OneshotScheduler{Time: testTime.Add(10 * time.Minute)},
+		SnoozedFrom:    root.ID,
+	}
+	other := &model.ReminderItem{
+		ID:             "other",
+		ConversationID: conversationID,
+		Scheduler:      &model.OneshotScheduler{Time: testTime.Add(-time.Minute)},
+	}
+
+	ctrl := gomock.NewController(t)
+	reminder := mock_repository.NewMockReminder(ctrl)
+	scheduler := mock_repository.NewMockScheduleSynchronizer(ctrl)
+	reminder.EXPECT().Get(gomock.Any(), conversationID, snoozed.ID).Return(snoozed, nil).Times(2)
+	reminder.EXPECT().Acknowledge(gomock.Any(), conversationID, root.ID, testTime).Return(nil)
+	reminder.EXPECT().List(gomock.Any(), conversationID).Return(model.ReminderItems{root, snoozed, other}, nil)
+	reminder.EXPECT().Delete(gomock.Any(), conversationID, snoozed.ID).Return(nil)
+	scheduler.EXPECT().Delete(gomock.Any(), conversationID, snoozed, testTime).Return(nil)
+	service := &ReminderImpl{
+		reminder:  reminder,
+		scheduler: scheduler,
+	}
+
+	err := service.Acknowledge(ctx, conversationID, snoozed.ID)
+	require.NoError(t, err)
+}
+
+func TestReminderImpl_Update(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := &model.ReminderItem{
+		ID:             "id1",
+		ConversationID: "c1",
+		Scheduler:      &model.DailyScheduler{Time: testTime.Add(time.Hour)},
+	}
+	paused := &model.ReminderItem{
+		ID:             old.ID,
+		ConversationID: old.ConversationID,
+		Scheduler:      old.Scheduler,
+		Paused:         true,
+		Revision:       1,
+	}
+	tests := []struct {
+		name         string
+		old          *model.ReminderItem
+		item         *model.ReminderItem
+		setup        func(*mock_repository.MockScheduleSynchronizer, *model.ReminderItem)
+		wantRevision int
+	}{
+		{
+			name: "change time",
+			item: &model.ReminderItem{
+				ID:             old.ID,
+				ConversationID: old.ConversationID,
+				Scheduler:      &model.DailyScheduler{Time: testTime.Add(30 * time.Minute)},
+			},
+			setup: func(m *mock_repository.MockScheduleSynchronizer, item *model.ReminderItem) {
+				m.EXPECT().Delete(gomock.Any(), old.ConversationID, old, testTime).Return(nil)
+				m.EXPECT().Create(gomock.Any(), item.ConversationID, item, testTime).Return(nil)
+			},
+			wantRevision: 1,
+		},
+		{
+			name: "pause",
+			item: &model.ReminderItem{
+				ID:             old.ID,
+				ConversationID: old.ConversationID,
+				Scheduler:      old.Scheduler,
+				Paused:         true,
+			},
+			setup: func(m *mock_repository.MockScheduleSynchronizer, item *model.ReminderItem) {
+				m.EXPECT().Delete(gomock.Any(), old.ConversationID, old, testTime).Return(nil)
+			},
+			wantRevision: 1,
+		},
+		{
+			name: "resume",
+			old:  paused,
+			item: &model.ReminderItem{
+				ID:             old.ID,
+				ConversationID: old.ConversationID,
+				Scheduler:      old.Scheduler,
+				Revision:       1,
+			},
+			setup: func(m *mock_repository.MockScheduleSynchronizer, item *model.ReminderItem) {
+				m.EXPECT().Create(gomock.Any(), item.ConversationID, item, testTime).Return(nil)
+			},
+			wantRevision: 2,
+		},
+		{
+			name: "no changes",
+			item: &model.ReminderItem{
+				ID:             old.ID,
+				ConversationID: old.ConversationID,
+				Scheduler:      old.Scheduler,
+			},
+			setup: func(m *mock_repository.MockScheduleSynchronizer, item *model.ReminderItem) {},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.True(t, testtime.SetTime(t, testTime))
+
+			ctrl := gomock.NewController(t)
+			reminder := mock_repository.NewMockReminder(ctrl)
+			scheduler := mock_repository.NewMockScheduleSynchronizer(ctrl)
+			stored := old
+			if tt.old != nil {
+				stored = tt.old
+			}
+			reminder.EXPECT().Get(gomock.Any(), old.ConversationID, old.ID).Return(stored, nil)
+			reminder.EXPECT().Update(gomock.Any(), tt.item).Return(nil)
+			tt.setup(scheduler, tt.item)
+			service := &ReminderImpl{
+				reminder:  reminder,
+				scheduler: scheduler,
+			}
+
+			err := service.Update(ctx, tt.item)
 			require.NoError(t, err)
+			assert.Equal(t, tt.wantRevision, tt.item.Revision)
 		})
 	}
 }
@@ -17,6 +17,8 @@ var Set = wire.NewSet(
 	wire.Bind(new(Bot), new(*BotImpl)),
 	NewWeather,
 	wire.Bind(new(Weather), new(*WeatherImpl)),
+	NewDictionary,
+	wire.Bind(new(Dictionary), new(*DictionaryImpl)),
 )
 
 var tracer = otel.Tracer("github.com/ww24/linebot/domain/service")
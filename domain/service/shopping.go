@@ -2,37 +2,65 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/xerrors"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/internal/code"
+	"github.com/ww24/linebot/internal/config"
+)
+
+const (
+	// historyPeriod is the period of the purchase history to suggest usual items.
+	historyPeriod = 365 * 24 * time.Hour
+	// maxSuggestions is the max number of suggested usual items.
+	maxSuggestions = 12
 )
 
 type Shopping interface {
-	List(ctx context.Context, conversationID model.ConversationID) (model.ShoppingItems, error)
-	AddItem(ctx context.Context, conversationID model.ConversationID, items ...*model.ShoppingItem) error
-	DeleteAllItem(ctx context.Context, conversationID model.ConversationID) error
-	DeleteItems(ctx context.Context, conversationID model.ConversationID, ids []string) error
+	List(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.ShoppingItems, error)
+	AddItem(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, items ...*model.ShoppingItem) (model.ShoppingItems, error)
+	DeleteAllItem(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (*model.ShoppingSnapshot, error)
+	DeleteItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) (*model.ShoppingSnapshot, error)
+	Undo(ctx context.Context, conversationID model.ConversationID, id model.ShoppingSnapshotID) (*model.ShoppingSnapshot, error)
+	UpdateItem(ctx context.Context, item *model.ShoppingItem) error
+	CheckItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) error
+	DeleteCheckedItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.ShoppingItems, error)
 	SetStatus(ctx context.Context, conversationID model.ConversationID) error
+	Lists(ctx context.Context, conversationID model.ConversationID) (model.ShoppingLists, error)
+	CurrentList(ctx context.Context, conversationID model.ConversationID) (*model.ShoppingList, error)
+	SwitchList(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error
+	CreateList(ctx context.Context, conversationID model.ConversationID, name string) (*model.ShoppingList, error)
+	RenameList(ctx context.Context, list *model.ShoppingList, name string) error
+	DeleteList(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error
+	Suggest(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.Suggestions, error)
 }
 
 type ShoppingImpl struct {
 	conversation repository.Conversation
 	shopping     repository.Shopping
+	aisleOrder   model.AisleOrder
 }
 
 func NewShopping(
 	conversation repository.Conversation,
 	shopping repository.Shopping,
+	conf *config.Shopping,
 ) *ShoppingImpl {
+	aisleOrder := model.DefaultAisleOrder
+	if len(conf.AisleOrder) > 0 {
+		aisleOrder = conf.AisleOrder
+	}
 	return &ShoppingImpl{
 		conversation: conversation,
 		shopping:     shopping,
+		aisleOrder:   aisleOrder,
 	}
 }
 
-func (s *ShoppingImpl) List(ctx context.Context, conversationID model.ConversationID) (model.ShoppingItems, error) {
+func (s *ShoppingImpl) List(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.ShoppingItems, error) {
 	ctx, span := tracer.Start(ctx, "Shopping#List")
 	defer span.End()
 
@@ -40,46 +68,210 @@ func (s *ShoppingImpl) List(ctx context.Context, conversationID model.Conversati
 		return nil, err
 	}
 
-	items, err := s.shopping.Find(ctx, conversationID)
+	items, err := s.shopping.Find(ctx, conversationID, listID)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
 	}
 
-	return items, nil
+	return model.ShoppingItems(items).Sorted(s.aisleOrder), nil
 }
 
-func (s *ShoppingImpl) AddItem(ctx context.Context, conversationID model.ConversationID, items ...*model.ShoppingItem) error {
+// AddItem adds the items and returns the added items,
+// the quantity of an item which is already on the list is merged into the existing one.
+func (s *ShoppingImpl) AddItem(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, items ...*model.ShoppingItem) (model.ShoppingItems, error) {
 	ctx, span := tracer.Start(ctx, "Shopping#AddItem")
 	defer span.End()
 
 	if err := s.SetStatus(ctx, conversationID); err != nil {
-		return err
+		return nil, err
 	}
-	if err := s.shopping.Add(ctx, items...); err != nil {
-		return xerrors.Errorf("failed to add shopping item: %w", err)
+
+	existing, err := s.shopping.Find(ctx, conversationID, listID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
 	}
-	return nil
+
+	added := make(model.ShoppingItems, 0, len(items))
+	newItems := make(model.ShoppingItems, 0, len(items))
+	for _, item := range items {
+		if same := newItems.FindSame(item); same != nil {
+			same.Quantity += item.Quantity
+			continue
+		}
+		// purchased items are not merged to buy the item again
+		if same := model.ShoppingItems(existing).Unchecked().FindSame(item); same != nil {
+			if err := s.shopping.AddQuantity(ctx, conversationID, listID, same.ID, item.Quantity); err != nil {
+				return nil, xerrors.Errorf("failed to add quantity: %w", err)
+			}
+			same.Quantity += item.Quantity
+			if added.FindSame(same) == nil {
+				added = append(added, same)
+			}
+			continue
+		}
+		newItems = append(newItems, item)
+		added = append(added, item)
+	}
+
+	if len(newItems) > 0 {
+		if err := s.shopping.Add(ctx, newItems...); err != nil {
+			return nil, xerrors.Errorf("failed to add shopping item: %w", err)
+		}
+	}
+
+	records := model.NewPurchaseRecords(items, model.PurchaseEventTypeAdded, time.Now())
+	if err := s.shopping.AppendHistory(ctx, records...); err != nil {
+		return nil, xerrors.Errorf("failed to append purchase history: %w", err)
+	}
+
+	return added.Sorted(s.aisleOrder), nil
 }
 
-func (s *ShoppingImpl) DeleteAllItem(ctx context.Context, conversationID model.ConversationID) error {
+// DeleteAllItem deletes all items of the list and returns the snapshot to undo,
+// the snapshot is nil if the list is empty.
+func (s *ShoppingImpl) DeleteAllItem(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (*model.ShoppingSnapshot, error) {
 	ctx, span := tracer.Start(ctx, "Shopping#DeleteAllItem")
 	defer span.End()
 
-	if err := s.shopping.DeleteAll(ctx, conversationID); err != nil {
-		return xerrors.Errorf("failed to delete all shopping items: %w", err)
+	items, err := s.shopping.Find(ctx, conversationID, listID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
+	}
+	snapshot, err := s.saveSnapshot(ctx, conversationID, listID, items)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.shopping.DeleteAll(ctx, conversationID, listID); err != nil {
+		return nil, xerrors.Errorf("failed to delete all shopping items: %w", err)
+	}
+	if err := s.appendCleared(ctx, items); err != nil {
+		return nil, err
 	}
 	if err := s.SetStatus(ctx, conversationID); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return snapshot, nil
 }
 
-func (s *ShoppingImpl) DeleteItems(ctx context.Context, conversationID model.ConversationID, ids []string) error {
+// DeleteItems deletes the items and returns the snapshot to undo,
+// the snapshot is nil if no item is deleted.
+func (s *ShoppingImpl) DeleteItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) (*model.ShoppingSnapshot, error) {
 	ctx, span := tracer.Start(ctx, "Shopping#DeleteItems")
 	defer span.End()
 
-	if err := s.shopping.BatchDelete(ctx, conversationID, ids); err != nil {
-		return xerrors.Errorf("failed to delete shopping item: %w", err)
+	items, err := s.shopping.Find(ctx, conversationID, listID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
+	}
+	deleted := model.ShoppingItems(items).FilterByIDs(ids)
+	snapshot, err := s.saveSnapshot(ctx, conversationID, listID, deleted)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.shopping.BatchDelete(ctx, conversationID, listID, ids); err != nil {
+		return nil, xerrors.Errorf("failed to delete shopping item: %w", err)
+	}
+	if err := s.appendCleared(ctx, deleted); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *ShoppingImpl) saveSnapshot(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, items model.ShoppingItems) (*model.ShoppingSnapshot, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	snapshot := model.NewShoppingSnapshot(conversationID, listID, items, time.Now())
+	if err := s.shopping.SaveSnapshot(ctx, snapshot); err != nil {
+		return nil, xerrors.Errorf("failed to save shopping snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Undo restores the deleted items of the snapshot,
+// it returns model.ErrShoppingSnapshotExpired if the snapshot is expired or already restored.
+func (s *ShoppingImpl) Undo(ctx context.Context, conversationID model.ConversationID, id model.ShoppingSnapshotID) (*model.ShoppingSnapshot, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#Undo")
+	defer span.End()
+
+	snapshot, err := s.shopping.GetSnapshot(ctx, conversationID, id)
+	if code.From(err) == code.NotFound {
+		return nil, xerrors.Errorf("snapshot %s is not found: %w", id, model.ErrShoppingSnapshotExpired)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get shopping snapshot: %w", err)
+	}
+	if snapshot.Expired(time.Now()) {
+		return nil, xerrors.Errorf("snapshot %s is expired: %w", id, model.ErrShoppingSnapshotExpired)
+	}
+	lists, err := s.Lists(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lists.Get(snapshot.ListID); err != nil {
+		return nil, xerrors.Errorf("the list of snapshot %s is deleted: %w", id, model.ErrShoppingSnapshotExpired)
+	}
+
+	if err := s.shopping.RestoreSnapshot(ctx, conversationID, id); err != nil {
+		if code.From(err) == code.NotFound {
+			return nil, xerrors.Errorf("snapshot %s is already restored: %w", id, model.ErrShoppingSnapshotExpired)
+		}
+		return nil, xerrors.Errorf("failed to restore shopping snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (s *ShoppingImpl) UpdateItem(ctx context.Context, item *model.ShoppingItem) error {
+	ctx, span := tracer.Start(ctx, "Shopping#UpdateItem")
+	defer span.End()
+
+	if err := s.shopping.Update(ctx, item); err != nil {
+		return xerrors.Errorf("failed to update shopping item: %w", err)
+	}
+	return nil
+}
+
+func (s *ShoppingImpl) CheckItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) error {
+	ctx, span := tracer.Start(ctx, "Shopping#CheckItems")
+	defer span.End()
+
+	if err := s.shopping.Check(ctx, conversationID, listID, ids, time.Now()); err != nil {
+		return xerrors.Errorf("failed to check shopping items: %w", err)
+	}
+	return nil
+}
+
+// DeleteCheckedItems deletes the purchased items and returns them.
+func (s *ShoppingImpl) DeleteCheckedItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.ShoppingItems, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#DeleteCheckedItems")
+	defer span.End()
+
+	items, err := s.shopping.Find(ctx, conversationID, listID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
+	}
+
+	checked := model.ShoppingItems(items).Checked()
+	if len(checked) == 0 {
+		return checked, nil
+	}
+	ids := make([]string, 0, len(checked))
+	for _, item := range checked {
+		ids = append(ids, item.ID)
+	}
+	if err := s.shopping.BatchDelete(ctx, conversationID, listID, ids); err != nil {
+		return nil, xerrors.Errorf("failed to delete shopping items: %w", err)
+	}
+	if err := s.appendCleared(ctx, checked); err != nil {
+		return nil, err
+	}
+	return checked, nil
+}
+
+func (s *ShoppingImpl) appendCleared(ctx context.Context, items model.ShoppingItems) error {
+	records := model.NewPurchaseRecords(items, model.PurchaseEventTypeCleared, time.Now())
+	if err := s.shopping.AppendHistory(ctx, records...); err != nil {
+		return xerrors.Errorf("failed to append purchase history: %w", err)
 	}
 	return nil
 }
@@ -91,9 +283,137 @@ func (s *ShoppingImpl) SetStatus(ctx context.Context, conversationID model.Conve
 	status := &model.ConversationStatus{
 		ConversationID: conversationID,
 		Type:           model.ConversationStatusTypeShopping,
+		UpdatedAt:      time.Now().Unix(),
 	}
 	if err := s.conversation.SetStatus(ctx, status); err != nil {
 		return xerrors.Errorf("failed to set status: %w", err)
 	}
 	return nil
 }
+
+// Lists returns the default list and the named lists.
+func (s *ShoppingImpl) Lists(ctx context.Context, conversationID model.ConversationID) (model.ShoppingLists, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#Lists")
+	defer span.End()
+
+	lists, err := s.shopping.FindLists(ctx, conversationID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping lists: %w", err)
+	}
+
+	return append(model.ShoppingLists{model.DefaultShoppingList(conversationID)}, lists...), nil
+}
+
+// CurrentList returns the current list of the conversation, it falls back to the default list.
+func (s *ShoppingImpl) CurrentList(ctx context.Context, conversationID model.ConversationID) (*model.ShoppingList, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#CurrentList")
+	defer span.End()
+
+	setting, err := s.conversation.GetSetting(ctx, conversationID)
+	if code.From(err) == code.NotFound {
+		return model.DefaultShoppingList(conversationID), nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get setting: %w", err)
+	}
+
+	lists, err := s.Lists(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := lists.Get(setting.ShoppingListID)
+	if err != nil {
+		// the list has been deleted
+		return model.DefaultShoppingList(conversationID), nil
+	}
+	return list, nil
+}
+
+func (s *ShoppingImpl) SwitchList(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error {
+	ctx, span := tracer.Start(ctx, "Shopping#SwitchList")
+	defer span.End()
+
+	setting, err := s.conversation.GetSetting(ctx, conversationID)
+	if code.From(err) == code.NotFound {
+		setting, err = &model.ConversationSetting{ConversationID: conversationID}, nil
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to get setting: %w", err)
+	}
+	setting.ShoppingListID = listID
+	if err := s.conversation.SetSetting(ctx, setting); err != nil {
+		return xerrors.Errorf("failed to set setting: %w", err)
+	}
+	return nil
+}
+
+// CreateList creates a named list and switches to it,
+// it returns code.AlreadyExists if the conversation has a list of the name.
+func (s *ShoppingImpl) CreateList(ctx context.Context, conversationID model.ConversationID, name string) (*model.ShoppingList, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#CreateList")
+	defer span.End()
+
+	lists, err := s.Lists(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if list, err := lists.FindByName(name); err == nil {
+		return nil, code.With(xerrors.Errorf("shopping list %q already exists", list.Name), code.AlreadyExists)
+	}
+
+	list := model.NewShoppingList(conversationID, name, time.Now())
+	if err := s.shopping.AddList(ctx, list); err != nil {
+		return nil, xerrors.Errorf("failed to add shopping list: %w", err)
+	}
+	if err := s.SwitchList(ctx, conversationID, list.ID); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *ShoppingImpl) RenameList(ctx context.Context, list *model.ShoppingList, name string) error {
+	ctx, span := tracer.Start(ctx, "Shopping#RenameList")
+	defer span.End()
+
+	if list.IsDefault() {
+		return xerrors.New("the default shopping list can not be renamed")
+	}
+	renamed := *list
+	renamed.Name = name
+	if err := s.shopping.UpdateList(ctx, &renamed); err != nil {
+		return xerrors.Errorf("failed to update shopping list: %w", err)
+	}
+	return nil
+}
+
+// DeleteList deletes the named list and its items, the current list falls back to the default list.
+func (s *ShoppingImpl) DeleteList(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error {
+	ctx, span := tracer.Start(ctx, "Shopping#DeleteList")
+	defer span.End()
+
+	if listID == model.DefaultShoppingListID {
+		return xerrors.New("the default shopping list can not be deleted")
+	}
+	if err := s.shopping.DeleteList(ctx, conversationID, listID); err != nil {
+		return xerrors.Errorf("failed to delete shopping list: %w", err)
+	}
+	return nil
+}
+
+// Suggest returns the usual items which are not on the list.
+func (s *ShoppingImpl) Suggest(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) (model.Suggestions, error) {
+	ctx, span := tracer.Start(ctx, "Shopping#Suggest")
+	defer span.End()
+
+	now := time.Now()
+	records, err := s.shopping.FindHistory(ctx, conversationID, now.Add(-historyPeriod))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find purchase history: %w", err)
+	}
+	items, err := s.shopping.Find(ctx, conversationID, listID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find shopping items: %w", err)
+	}
+
+	return model.PurchaseRecords(records).Suggest(items, now, maxSuggestions), nil
+}
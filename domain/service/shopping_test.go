@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/code"
+	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/mock/mock_repository"
+)
+
+func TestShoppingImpl_AddItem(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	conversation := mock_repository.NewMockConversation(ctrl)
+	conversation.EXPECT().SetStatus(gomock.Any(), gomock.Any()).Return(nil)
+	shopping := mock_repository.NewMockShopping(ctrl)
+	eggs := &model.ShoppingItem{ID: "eggs", Name: "卵", Quantity: 1, Unit: "パック", ConversationID: conversationID}
+	shopping.EXPECT().Find(gomock.Any(), conversationID, model.DefaultShoppingListID).Return([]*model.ShoppingItem{eggs}, nil)
+	shopping.EXPECT().AddQuantity(gomock.Any(), conversationID, model.DefaultShoppingListID, "eggs", 2).Return(nil)
+
+	milk := model.NewShoppingItem(conversationID, model.DefaultShoppingListID, "牛乳", 1, "", 1, now)
+	shopping.EXPECT().Add(gomock.Any(), milk).Return(nil)
+	// every addition is recorded in the purchase history
+	shopping.EXPECT().AppendHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewShopping(conversation, shopping, &config.Shopping{})
+	got, err := s.AddItem(ctx, conversationID, model.DefaultShoppingListID,
+		model.NewShoppingItem(conversationID, model.DefaultShoppingListID, "卵", 2, "パック", 0, now),
+		milk,
+		model.NewShoppingItem(conversationID, model.DefaultShoppingListID, "牛乳", 2, "", 2, now),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "・卵 3パック\n・牛乳 x3", got.Print(model.ListTypeDotted, nil))
+}
+
+func TestShoppingImpl_DeleteCheckedItems(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	shopping := mock_repository.NewMockShopping(ctrl)
+	items := []*model.ShoppingItem{
+		{ID: "1", Name: "卵", Quantity: 1, ConversationID: conversationID, CheckedAt: 1666416720},
+		{ID: "2", Name: "牛乳", Quantity: 1, ConversationID: conversationID},
+		{ID: "3", Name: "りんご", Quantity: 1, ConversationID: conversationID, CheckedAt: 1666416727},
+	}
+	shopping.EXPECT().Find(gomock.Any(), conversationID, model.DefaultShoppingListID).Return(items, nil)
+	shopping.EXPECT().BatchDelete(gomock.Any(), conversationID, model.DefaultShoppingListID, []string{"1", "3"}).Return(nil)
+	shopping.EXPECT().AppendHistory(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, records ...*model.PurchaseRecord) error {
+			for i, name := range []string{"卵", "りんご"} {
+				assert.Equal(t, name, records[i].Name)
+				assert.Equal(t, model.PurchaseEventTypeCleared, records[i].Event)
+			}
+			return nil
+		})
+
+	s := NewShopping(nil, shopping, &config.Shopping{})
+	got, err := s.DeleteCheckedItems(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ShoppingItems{items[0], items[2]}, got)
+}
+
+func TestShoppingImpl_CurrentList(t *testing.T) {
+	t.Parallel()
+	const conversationID = model.ConversationID("c1")
+	groceries := &model.ShoppingList{ID: "groceries", ConversationID: conversationID, Name: "食料品"}
+
+	tests := []struct {
+		name    string
+		setting *model.ConversationSetting
+		err     error
+		want    *model.ShoppingList
+	}{
+		{
+			name: "no setting",
+			err:  code.With(errors.New("not found"), code.NotFound),
+			want: model.DefaultShoppingList(conversationID),
+		},
+		{
+			name:    "named list",
+			setting: &model.ConversationSetting{ConversationID: conversationID, ShoppingListID: "groceries"},
+			want:    groceries,
+		},
+		{
+			name:    "deleted list",
+			setting: &model.ConversationSetting{ConversationID: conversationID, ShoppingListID: "deleted"},
+			want:    model.DefaultShoppingList(conversationID),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			conversation := mock_repository.NewMockConversation(ctrl)
+			conversation.EXPECT().GetSetting(gomock.Any(), conversationID).Return(tt.setting, tt.err)
+			shopping := mock_repository.NewMockShopping(ctrl)
+			shopping.EXPECT().FindLists(gomock.Any(), conversationID).Return([]*model.ShoppingList{groceries}, nil).AnyTimes()
+
+			s := NewShopping(conversation, shopping, &config.Shopping{})
+			got, err := s.CurrentList(ctx, conversationID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShoppingImpl_CreateList(t *testing.T) {
+	t.Parallel()
+	const conversationID = model.ConversationID("c1")
+	groceries := &model.ShoppingList{ID: "groceries", ConversationID: conversationID, Name: "食料品"}
+
+	t.Run("created", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		conversation := mock_repository.NewMockConversation(ctrl)
+		conversation.EXPECT().GetSetting(gomock.Any(), conversationID).
+			Return(&model.ConversationSetting{ConversationID: conversationID, Timezone: "Asia/Tokyo"}, nil)
+		shopping := mock_repository.NewMockShopping(ctrl)
+		shopping.EXPECT().FindLists(gomock.Any(), conversationID).Return([]*model.ShoppingList{groceries}, nil)
+		shopping.EXPECT().AddList(gomock.Any(), gomock.Any()).Return(nil)
+
+		s := NewShopping(conversation, shopping, &config.Shopping{})
+		var saved *model.ConversationSetting
+		conversation.EXPECT().SetSetting(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, setting *model.ConversationSetting) error {
+				saved = setting
+				return nil
+			})
+		list, err := s.CreateList(ctx, conversationID, "日用品")
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", saved.Timezone)
+		assert.Equal(t, list.ID, saved.ShoppingListID)
+		assert.Equal(t, "日用品", list.Name)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		shopping := mock_repository.NewMockShopping(ctrl)
+		shopping.EXPECT().FindLists(gomock.Any(), conversationID).Return([]*model.ShoppingList{groceries}, nil)
+
+		s := NewShopping(nil, shopping, &config.Shopping{})
+		_, err := s.CreateList(ctx, conversationID, "食料品")
+		assert.Equal(t, code.AlreadyExists, code.From(err))
+	})
+}
+
+func TestShoppingImpl_DeleteItems(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const conversationID = model.ConversationID("c1")
+
+	ctrl := gomock.NewController(t)
+	shopping := mock_repository.NewMockShopping(ctrl)
+	items := []*model.ShoppingItem{
+		{ID: "1", Name: "卵", Quantity: 1, ConversationID: conversationID, CreatedAt: 1666416720},
+		{ID: "2", Name: "牛乳", Quantity: 1, ConversationID: conversationID, CreatedAt: 1666416720, Order: 1},
+	}
+	shopping.EXPECT().Find(gomock.Any(), conversationID, model.DefaultShoppingListID).Return(items, nil)
+	// the snapshot is saved before the items are deleted
+	gomock.InOrder(
+		shopping.EXPECT().SaveSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, snapshot *model.ShoppingSnapshot) error {
+				assert.Equal(t, model.ShoppingItems{items[1]}, snapshot.Items)
+				return nil
+			}),
+		shopping.EXPECT().BatchDelete(gomock.Any(), conversationID, model.DefaultShoppingListID, []string{"2"}).Return(nil),
+	)
+	shopping.EXPECT().AppendHistory(gomock.Any(), gomock.Any()).Return(nil)
+
+	s := NewShopping(nil, shopping, &config.Shopping{})
+	snapshot, err := s.DeleteItems(ctx, conversationID, model.DefaultShoppingListID, []string{"2"})
+	require.NoError(t, err)
+	assert.Equal(t, model.ShoppingItems{items[1]}, snapshot.Items)
+}
+
+func TestShoppingImpl_Undo(t *testing.T) {
+	t.Parallel()
+	const conversationID = model.ConversationID("c1")
+	now := time.Now()
+	notFound := code.With(errors.New("not found"), code.NotFound)
+	snapshot := func(listID model.ShoppingListID, t time.Time) *model.ShoppingSnapshot {
+		items := model.ShoppingItems{{ID: "1", Name: "卵", Quantity: 1, ConversationID: conversationID, CreatedAt: 1666416720}}
+		return &model.ShoppingSnapshot{ID: "s1", ConversationID: conversationID, ListID: listID, Items: items, CreatedAt: t.Unix()}
+	}
+
+	tests := []struct {
+		name       string
+		snapshot   *model.ShoppingSnapshot
+		getErr     error
+		restore    bool
+		restoreErr error
+		wantErr    error
+	}{
+		{
+			name:     "restored",
+			snapshot: snapshot(model.DefaultShoppingListID, now),
+			restore:  true,
+		},
+		{
+			name:    "not found",
+			getErr:  notFound,
+			wantErr: model.ErrShoppingSnapshotExpired,
+		},
+		{
+			name:     "expired",
+			snapshot: snapshot(model.DefaultShoppingListID, now.Add(-model.ShoppingUndoWindow)),
+			wantErr:  model.ErrShoppingSnapshotExpired,
+		},
+		{
+			name:     "list deleted",
+			snapshot: snapshot("deleted", now),
+			wantErr:  model.ErrShoppingSnapshotExpired,
+		},
+		{
+			name:       "already restored",
+			snapshot:   snapshot(model.DefaultShoppingListID, now),
+			restore:    true,
+			restoreErr: notFound,
+			wantErr:    model.ErrShoppingSnapshotExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			shopping := mock_repository.NewMockShopping(ctrl)
+			shopping.EXPECT().GetSnapshot(gomock.Any(), conversationID, model.ShoppingSnapshotID("s1")).Return(tt.snapshot, tt.getErr)
+			shopping.EXPECT().FindLists(gomock.Any(), conversationID).Return(nil, nil).AnyTimes()
+			if tt.restore {
+				shopping.EXPECT().RestoreSnapshot(gomock.Any(), conversationID, model.ShoppingSnapshotID("s1")).Return(tt.restoreErr)
+			}
+
+			s := NewShopping(nil, shopping, &config.Shopping{})
+			got, err := s.Undo(ctx, conversationID, "s1")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.snapshot, got)
+		})
+	}
+}
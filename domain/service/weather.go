@@ -20,7 +20,7 @@ const (
 
 type Weather interface {
 	SaveImage(context.Context, io.Reader) error
-	LatestImage(context.Context) (string, error)
+	LatestImage(context.Context, *time.Location) (string, error)
 }
 
 type WeatherImpl struct {
@@ -63,15 +63,20 @@ func (w *WeatherImpl) SaveImage(ctx context.Context, r io.Reader) error {
 	return nil
 }
 
-func (w *WeatherImpl) LatestImage(ctx context.Context) (string, error) {
+// LatestImage returns the URL of the latest weather image of today in loc,
+// the default location is used if loc is nil.
+func (w *WeatherImpl) LatestImage(ctx context.Context, loc *time.Location) (string, error) {
 	ctx, span := tracer.Start(ctx, "Weather#LatestImage")
 	defer span.End()
 
-	now := time.Now().In(w.loc)
+	if loc == nil {
+		loc = w.loc
+	}
+	now := time.Now().In(loc)
 
-	name, err := w.imageStore.Get(ctx, now, weatherImageTTL)
+	name, err := w.imageStore.Get(ctx, now, loc, weatherImageTTL)
 	if code.From(err) == code.NotFound && now.Add(-weatherImageTTL).Day() != now.Day() {
-		name, err = w.imageStore.Get(ctx, now.Add(-weatherImageTTL), weatherImageTTL)
+		name, err = w.imageStore.Get(ctx, now.Add(-weatherImageTTL), loc, weatherImageTTL)
 	}
 	if err != nil {
 		return "", xerrors.Errorf("imageStore.Get: %w", err)
@@ -20,9 +20,11 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 	const urlPrefix = "https://example.com/image"
 	ctx := context.Background()
 	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	newYork := time.FixedZone("America/New_York", -5*60*60)
 	tests := []struct {
 		name     string
 		setup    func(*mock_repository.MockWeatherImageStore, time.Time)
+		loc      *time.Location
 		time     time.Time
 		want     string
 		wantCode code.Code
@@ -31,7 +33,7 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 			name: "success",
 			setup: func(m *mock_repository.MockWeatherImageStore, t time.Time) {
 				m.EXPECT().Get(
-					gomock.Any(), t,
+					gomock.Any(), t, loc,
 					weatherImageTTL,
 				).Return("20220101/image.png", nil)
 			},
@@ -44,11 +46,11 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 			setup: func(m *mock_repository.MockWeatherImageStore, t time.Time) {
 				gomock.InOrder(
 					m.EXPECT().Get(
-						gomock.Any(), t,
+						gomock.Any(), t, loc,
 						weatherImageTTL,
 					).Return("", code.With(errors.New("not found"), code.NotFound)),
 					m.EXPECT().Get(
-						gomock.Any(), t.Add(-weatherImageTTL),
+						gomock.Any(), t.Add(-weatherImageTTL), loc,
 						weatherImageTTL,
 					).Return("20211231/image.png", nil),
 				)
@@ -62,11 +64,11 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 			setup: func(m *mock_repository.MockWeatherImageStore, t time.Time) {
 				gomock.InOrder(
 					m.EXPECT().Get(
-						gomock.Any(), t,
+						gomock.Any(), t, loc,
 						weatherImageTTL,
 					).Return("", code.With(errors.New("not found"), code.NotFound)),
 					m.EXPECT().Get(
-						gomock.Any(), t.Add(-weatherImageTTL),
+						gomock.Any(), t.Add(-weatherImageTTL), loc,
 						weatherImageTTL,
 					).Return("", code.With(errors.New("not found"), code.NotFound)),
 				)
@@ -75,11 +77,24 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 			want:     "",
 			wantCode: code.NotFound,
 		},
+		{
+			name: "location of the conversation",
+			setup: func(m *mock_repository.MockWeatherImageStore, t time.Time) {
+				m.EXPECT().Get(
+					gomock.Any(), t.In(newYork), newYork,
+					weatherImageTTL,
+				).Return("20220101/image.png", nil)
+			},
+			loc:      newYork,
+			time:     time.Date(2022, 1, 1, 12, 0, 0, 0, loc),
+			want:     urlPrefix + "/20220101/image.png",
+			wantCode: code.OK,
+		},
 		{
 			name: "unexpected error",
 			setup: func(m *mock_repository.MockWeatherImageStore, t time.Time) {
 				m.EXPECT().Get(
-					gomock.Any(), t,
+					gomock.Any(), t, loc,
 					weatherImageTTL,
 				).Return("", errors.New("unexpected"))
 			},
@@ -103,7 +118,7 @@ func TestWeatherImpl_LatestImage(t *testing.T) {
 				urlPrefix:  urlPrefix,
 			}
 
-			got, err := service.LatestImage(ctx)
+			got, err := service.LatestImage(ctx, tt.loc)
 			assert.Equal(t, tt.wantCode, code.From(err))
 			assert.Equal(t, tt.want, got)
 		})
@@ -20,9 +20,14 @@ type ReminderItem struct {
 	Title        string `json:"title"`
 	SubTitle     string `json:"subTitle"`
 	Next         string `json:"next"`
+	LastRun      string `json:"lastRun"`
+	Paused       bool   `json:"paused"`
+	EditTarget   string `json:"editTarget"`
+	PauseTarget  string `json:"pauseTarget"`
 	DeleteTarget string `json:"deleteTarget"`
 }
 
+// makeReminderListMessage renders the items, the next schedule is shown in the location of t.
 func makeReminderListMessage(items []*model.ReminderItem, t time.Time) ([]byte, error) {
 	reminderItems := make([]*ReminderItem, 0, len(items))
 	for _, item := range items {
@@ -51,15 +56,32 @@ func makeReminderListMessage(items []*model.ReminderItem, t time.Time) ([]byte,
 
 func toReminderItem(item *model.ReminderItem, t time.Time) *ReminderItem {
 	var next string
-	if schedule, err := item.Scheduler.Next(t); err != nil {
+	if schedule, err := item.Next(t); err != nil {
 		next = "ERROR: failed to calculate next schedule"
 	} else {
-		next = schedule.Format("01/02 15:04")
+		next = schedule.In(t.Location()).Format("01/02 15:04")
+	}
+	pauseTarget := "Reminder#pause#" + string(item.ID)
+	if item.Paused {
+		next = "paused"
+		pauseTarget = "Reminder#resume#" + string(item.ID)
+	}
+	var lastRun string
+	if d := item.LastDelivery; d != nil {
+		ranAt := d.StartedAt
+		if d.Outcome == model.DeliveryOutcomeSent {
+			ranAt = d.SentAt
+		}
+		lastRun = ranAt.In(t.Location()).Format("01/02 15:04") + " (" + d.Outcome.String() + ")"
 	}
 	return &ReminderItem{
-		Title:        item.Executor.Type.UIText(),
-		SubTitle:     item.Scheduler.UIText(),
+		Title:        item.Executor.UIText(),
+		SubTitle:     item.UIText(),
 		Next:         next,
+		LastRun:      lastRun,
+		Paused:       item.Paused,
+		EditTarget:   "Reminder#edit#" + string(item.ID),
+		PauseTarget:  pauseTarget,
 		DeleteTarget: "Reminder#delete#" + string(item.ID),
 	}
 }
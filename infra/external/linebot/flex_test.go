@@ -42,6 +42,24 @@ func TestMakeReminderListMessage(t *testing.T) {
 	require.NoError(t, err, "invalid flex message")
 }
 
+func TestToReminderItem_Location(t *testing.T) {
+	t.Parallel()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	item := &model.ReminderItem{
+		ID:             "id1",
+		ConversationID: "conversationID1",
+		Scheduler: &model.OneshotScheduler{
+			Time: time.Date(2020, 1, 3, 12, 30, 0, 0, time.UTC),
+		},
+		Executor: &model.Executor{
+			Type: model.ExecutorTypeShoppingList,
+		},
+	}
+	got := toReminderItem(item, time.Date(2020, 1, 1, 0, 0, 0, 0, newYork))
+	assert.Equal(t, "01/03 07:30", got.Next)
+}
+
 func TestToReminderItem(t *testing.T) {
 	t.Parallel()
 	testTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -64,6 +82,8 @@ func TestToReminderItem(t *testing.T) {
 				Title:        "買い物リスト",
 				SubTitle:     "at 2020-01-03 12:30.",
 				Next:         "01/03 12:30",
+				EditTarget:   "Reminder#edit#id1",
+				PauseTarget:  "Reminder#pause#id1",
 				DeleteTarget: "Reminder#delete#id1",
 			},
 		},
@@ -82,9 +102,145 @@ func TestToReminderItem(t *testing.T) {
 				Title:        "買い物リスト",
 				SubTitle:     "at 12:30 every day.",
 				Next:         "01/01 12:30",
+				EditTarget:   "Reminder#edit#id2",
+				PauseTarget:  "Reminder#pause#id2",
 				DeleteTarget: "Reminder#delete#id2",
 			},
 		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id3",
+				ConversationID: "conversationID1",
+				Scheduler: &model.WeeklyScheduler{
+					Time:     time.Date(2020, 1, 3, 12, 30, 0, 0, time.UTC),
+					Weekdays: []time.Weekday{time.Friday, time.Tuesday},
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+			},
+			want: &ReminderItem{
+				Title:        "買い物リスト",
+				SubTitle:     "at 12:30 every Tue, Fri.",
+				Next:         "01/03 12:30",
+				EditTarget:   "Reminder#edit#id3",
+				PauseTarget:  "Reminder#pause#id3",
+				DeleteTarget: "Reminder#delete#id3",
+			},
+		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id4",
+				ConversationID: "conversationID1",
+				Scheduler: &model.MonthlyScheduler{
+					Time: time.Date(2020, 1, 3, 12, 30, 0, 0, time.UTC),
+					Day:  25,
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+			},
+			want: &ReminderItem{
+				Title:        "買い物リスト",
+				SubTitle:     "at 12:30 on day 25 of every month.",
+				Next:         "01/25 12:30",
+				EditTarget:   "Reminder#edit#id4",
+				PauseTarget:  "Reminder#pause#id4",
+				DeleteTarget: "Reminder#delete#id4",
+			},
+		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id5",
+				ConversationID: "conversationID1",
+				Scheduler: &model.CronScheduler{
+					Spec:     "0 9 * * 1-5",
+					Location: time.UTC,
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+			},
+			want: &ReminderItem{
+				Title:        "買い物リスト",
+				SubTitle:     `by cron "0 9 * * 1-5" (UTC).`,
+				Next:         "01/01 09:00",
+				EditTarget:   "Reminder#edit#id5",
+				PauseTarget:  "Reminder#pause#id5",
+				DeleteTarget: "Reminder#delete#id5",
+			},
+		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id6",
+				ConversationID: "conversationID1",
+				Scheduler: &model.DailyScheduler{
+					Time: time.Date(2020, 1, 3, 7, 0, 0, 0, time.UTC),
+				},
+				Executor: &model.Executor{
+					Type:    model.ExecutorTypeMessage,
+					Payload: "ゴミ出し",
+				},
+			},
+			want: &ReminderItem{
+				Title:        "ゴミ出し",
+				SubTitle:     "at 07:00 every day.",
+				Next:         "01/01 07:00",
+				EditTarget:   "Reminder#edit#id6",
+				PauseTarget:  "Reminder#pause#id6",
+				DeleteTarget: "Reminder#delete#id6",
+			},
+		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id7",
+				ConversationID: "conversationID1",
+				Scheduler: &model.DailyScheduler{
+					Time: time.Date(2020, 1, 3, 7, 0, 0, 0, time.UTC),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeWeather,
+				},
+				Paused: true,
+			},
+			want: &ReminderItem{
+				Title:        "天気",
+				SubTitle:     "at 07:00 every day.",
+				Next:         "paused",
+				Paused:       true,
+				EditTarget:   "Reminder#edit#id7",
+				PauseTarget:  "Reminder#resume#id7",
+				DeleteTarget: "Reminder#delete#id7",
+			},
+		},
+		{
+			item: &model.ReminderItem{
+				ID:             "id8",
+				ConversationID: "conversationID1",
+				Scheduler: &model.DailyScheduler{
+					Time: time.Date(2020, 1, 3, 7, 0, 0, 0, time.UTC),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				Exclusion: &model.Exclusion{
+					Ranges: []*model.DateRange{
+						{
+							Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+							End:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			want: &ReminderItem{
+				Title:        "買い物リスト",
+				SubTitle:     "at 07:00 every day. except 2020-01-01/2020-01-01.",
+				Next:         "01/02 07:00",
+				EditTarget:   "Reminder#edit#id8",
+				PauseTarget:  "Reminder#pause#id8",
+				DeleteTarget: "Reminder#delete#id8",
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
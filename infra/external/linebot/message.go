@@ -1,42 +1,127 @@
 package linebot
 
 import (
+	"strings"
 	"time"
 
 	"github.com/line/line-bot-sdk-go/v7/linebot"
+	"golang.org/x/text/message"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/internal/i18n"
+)
+
+const (
+	datetimePickerLayout = "2006-01-02T15:04"
+
+	// maxQuickReplyLabel is the max length of a quick reply label.
+	maxQuickReplyLabel = 20
+	// maxShoppingListButtons keeps the quick replies of shopping lists within the limit of 13.
+	maxShoppingListButtons = 10
+	// maxSuggestionButtons keeps the quick replies of suggestions within the limit of 13.
+	maxSuggestionButtons = 12
+	// maxDeleteChoiceButtons keeps the quick replies of deletion candidates with "全部削除" and "キャンセル" within the limit of 13.
+	maxDeleteChoiceButtons = 11
 )
 
 // MessageProviderSet implements repository.MessageProviderSet.
-type MessageProviderSet struct{}
+// The labels of the quick replies are printed in the language of the set, Japanese by default.
+type MessageProviderSet struct {
+	printer *message.Printer
+}
 
 func NewMessageProviderSet() *MessageProviderSet {
-	return &MessageProviderSet{}
+	return &MessageProviderSet{
+		printer: i18n.Printer(model.LanguageJapanese.Tag()),
+	}
+}
+
+// Localize returns the set which labels the quick replies in the language.
+func (s *MessageProviderSet) Localize(lang model.Language) repository.MessageProviderSet {
+	return &MessageProviderSet{
+		printer: i18n.Printer(lang.Tag()),
+	}
 }
 
 func (s *MessageProviderSet) Text(text string) repository.MessageProvider {
 	return &TextMessage{text: text}
 }
 
+// Prompt returns the message which asks for an input of a flow with the quick reply to cancel the flow.
+func (s *MessageProviderSet) Prompt(text string) repository.MessageProvider {
+	return &Prompt{
+		text:    text,
+		printer: s.printer,
+	}
+}
+
 func (s *MessageProviderSet) ShoppingDeleteConfirmation(text string) repository.MessageProvider {
-	return &ShoppingDeleteConfirmation{text: text}
+	return &ShoppingDeleteConfirmation{
+		text:    text,
+		printer: s.printer,
+	}
+}
+
+// ShoppingDeleteChoices returns the message with the quick replies to choose the items to delete.
+func (s *MessageProviderSet) ShoppingDeleteChoices(text string, items model.ShoppingItems) repository.MessageProvider {
+	return &ShoppingDeleteChoices{
+		text:    text,
+		items:   items,
+		printer: s.printer,
+	}
 }
 
 func (s *MessageProviderSet) ShoppingMenu(text string, rt model.ShoppingReplyType) repository.MessageProvider {
 	return &ShoppingMenu{
 		text:      text,
 		replyType: rt,
+		printer:   s.printer,
+	}
+}
+
+// ShoppingUndo returns the shopping menu with the quick reply to restore the deleted items.
+func (s *MessageProviderSet) ShoppingUndo(text string, rt model.ShoppingReplyType, snapshotID model.ShoppingSnapshotID) repository.MessageProvider {
+	return &ShoppingMenu{
+		text:      text,
+		replyType: rt,
+		undoData:  "Shopping#undo#" + string(snapshotID),
+		printer:   s.printer,
+	}
+}
+
+func (s *MessageProviderSet) ShoppingLists(text string, lists model.ShoppingLists, current model.ShoppingListID) repository.MessageProvider {
+	return &ShoppingLists{
+		text:    text,
+		lists:   lists,
+		current: current,
+		printer: s.printer,
 	}
 }
 
-func (s *MessageProviderSet) ReminderMenu(text string, rt model.ReminderReplyType, items []*model.ReminderItem) repository.MessageProvider {
+func (s *MessageProviderSet) ShoppingListDeleteConfirmation(text string, listID model.ShoppingListID) repository.MessageProvider {
+	return &ShoppingListDeleteConfirmation{
+		text:    text,
+		listID:  listID,
+		printer: s.printer,
+	}
+}
+
+func (s *MessageProviderSet) ShoppingSuggestions(text string, suggestions model.Suggestions) repository.MessageProvider {
+	return &ShoppingSuggestions{
+		text:        text,
+		suggestions: suggestions,
+		printer:     s.printer,
+	}
+}
+
+func (s *MessageProviderSet) ReminderMenu(text string, rt model.ReminderReplyType, items []*model.ReminderItem, loc *time.Location) repository.MessageProvider {
 	reminderMenu := &ReminderMenu{
 		text:      text,
 		replyType: rt,
+		printer:   s.printer,
 	}
-	t := time.Now()
+	t := time.Now().In(loc)
 	if len(items) == 0 {
 		return reminderMenu
 	}
@@ -51,23 +136,60 @@ func (s *MessageProviderSet) ReminderMenu(text string, rt model.ReminderReplyTyp
 
 func (s *MessageProviderSet) ReminderChoices(text string, labels []string, types []model.ExecutorType) repository.MessageProvider {
 	return &ReminderChoices{
-		text:   text,
-		labels: labels,
-		types:  types,
+		text:    text,
+		labels:  labels,
+		types:   types,
+		printer: s.printer,
+	}
+}
+
+func (s *MessageProviderSet) ReminderScheduleChoices(text string, executorType model.ExecutorType) repository.MessageProvider {
+	return &ReminderScheduleChoices{
+		text:         text,
+		executorType: executorType,
+		printer:      s.printer,
 	}
 }
 
 func (s *MessageProviderSet) TimePicker(text, data string) repository.MessageProvider {
 	return &TimePicker{
-		text: text,
-		data: data,
+		text:    text,
+		data:    data,
+		printer: s.printer,
+	}
+}
+
+func (s *MessageProviderSet) DateTimePicker(text, data string, minTime time.Time) repository.MessageProvider {
+	return &DateTimePicker{
+		text:    text,
+		data:    data,
+		minTime: minTime,
+		printer: s.printer,
 	}
 }
 
 func (s *MessageProviderSet) ReminderDeleteConfirmation(text, data string) repository.MessageProvider {
 	return &ReminderDeleteConfirmation{
-		text: text,
-		data: data,
+		text:    text,
+		data:    data,
+		printer: s.printer,
+	}
+}
+
+func (s *MessageProviderSet) ReminderAdded(text, undoData string) repository.MessageProvider {
+	return &ReminderAdded{
+		text:     text,
+		undoData: undoData,
+		printer:  s.printer,
+	}
+}
+
+// ReminderActions attaches snooze and acknowledge quick replies to the pushed reminder.
+func (s *MessageProviderSet) ReminderActions(p repository.MessageProvider, itemID model.ReminderItemID) repository.MessageProvider {
+	return &ReminderActions{
+		provider: p,
+		itemID:   itemID,
+		printer:  s.printer,
 	}
 }
 
@@ -86,9 +208,28 @@ func (p *TextMessage) ToMessage() linebot.SendingMessage {
 	return linebot.NewTextMessage(p.text)
 }
 
+// Prompt implements repository.MessageProvider.
+type Prompt struct {
+	text    string
+	printer *message.Printer
+}
+
+func (p *Prompt) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(p.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
+		Items: []*linebot.QuickReplyButton{
+			{Action: cancelAction(p.printer)},
+		},
+	})
+
+	return msg
+}
+
 // Message implements repository.MessageProvider.
 type ShoppingDeleteConfirmation struct {
-	text string
+	text    string
+	printer *message.Printer
 }
 
 // AsMessage assigns the message to the given *linebot.SendingMessage.
@@ -97,8 +238,8 @@ func (p *ShoppingDeleteConfirmation) ToMessage() linebot.SendingMessage {
 	msg = linebot.NewTextMessage(p.text)
 	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
 		Items: []*linebot.QuickReplyButton{
-			{Action: linebot.NewPostbackAction("YES", "Shopping#deleteConfirm", "", "YES", "", "")},
-			{Action: linebot.NewPostbackAction("NO", "Shopping#deleteCancel", "", "NO", "", "")},
+			{Action: postbackAction(p.printer, "YES", "Shopping#deleteConfirm")},
+			{Action: postbackAction(p.printer, "NO", "Shopping#deleteCancel")},
 		},
 	})
 	return msg
@@ -108,44 +249,221 @@ func (p *ShoppingDeleteConfirmation) ToMessage() linebot.SendingMessage {
 type ShoppingMenu struct {
 	text      string
 	replyType model.ShoppingReplyType
+	undoData  string
+	printer   *message.Printer
 }
 
 func (p *ShoppingMenu) ToMessage() linebot.SendingMessage {
 	var msg linebot.SendingMessage
 	msg = linebot.NewTextMessage(p.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{Items: p.quickReplies()})
+
+	return msg
+}
 
+func (p *ShoppingMenu) quickReplies() []*linebot.QuickReplyButton {
+	var items []*linebot.QuickReplyButton
 	switch p.replyType {
 	case model.ShoppingReplyTypeEmptyList:
-		msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
-			Items: []*linebot.QuickReplyButton{
-				{Action: linebot.NewPostbackAction("追加", "Shopping#add", "", "追加", "", "")},
-			},
-		})
+		items = []*linebot.QuickReplyButton{
+			{Action: postbackAction(p.printer, "追加", "Shopping#add")},
+			{Action: postbackAction(p.printer, "いつもの", "Shopping#usual")},
+			{Action: postbackAction(p.printer, "リスト切替", "Shopping#lists")},
+		}
 	case model.ShoppingReplyTypeWithoutView:
-		msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
-			Items: []*linebot.QuickReplyButton{
-				{Action: linebot.NewPostbackAction("削除", "Shopping#delete", "", "削除", "", "")},
-				{Action: linebot.NewPostbackAction("追加", "Shopping#add", "", "追加", "", "")},
-			},
-		})
+		items = []*linebot.QuickReplyButton{
+			{Action: postbackAction(p.printer, "削除", "Shopping#delete")},
+			{Action: postbackAction(p.printer, "追加", "Shopping#add")},
+			{Action: postbackAction(p.printer, "いつもの", "Shopping#usual")},
+			{Action: postbackAction(p.printer, "リスト切替", "Shopping#lists")},
+		}
+	case model.ShoppingReplyTypeWithChecked:
+		items = []*linebot.QuickReplyButton{
+			{Action: postbackAction(p.printer, "削除", "Shopping#delete")},
+			{Action: postbackAction(p.printer, "追加", "Shopping#add")},
+			{Action: postbackAction(p.printer, "購入済みを削除", "Shopping#clearChecked")},
+			{Action: postbackAction(p.printer, "いつもの", "Shopping#usual")},
+			{Action: postbackAction(p.printer, "リスト切替", "Shopping#lists")},
+		}
 	default:
-		msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
-			Items: []*linebot.QuickReplyButton{
-				{Action: linebot.NewPostbackAction("削除", "Shopping#delete", "", "削除", "", "")},
-				{Action: linebot.NewPostbackAction("追加", "Shopping#add", "", "追加", "", "")},
-				{Action: linebot.NewPostbackAction("表示", "Shopping#view", "", "表示", "", "")},
-			},
+		items = []*linebot.QuickReplyButton{
+			{Action: postbackAction(p.printer, "削除", "Shopping#delete")},
+			{Action: postbackAction(p.printer, "追加", "Shopping#add")},
+			{Action: postbackAction(p.printer, "表示", "Shopping#view")},
+			{Action: postbackAction(p.printer, "いつもの", "Shopping#usual")},
+			{Action: postbackAction(p.printer, "リスト切替", "Shopping#lists")},
+		}
+	}
+	if p.undoData != "" {
+		undo := &linebot.QuickReplyButton{
+			Action: postbackAction(p.printer, "元に戻す", p.undoData),
+		}
+		items = append([]*linebot.QuickReplyButton{undo}, items...)
+	}
+
+	return items
+}
+
+// ShoppingLists implements repository.MessageProvider.
+type ShoppingLists struct {
+	text    string
+	lists   model.ShoppingLists
+	current model.ShoppingListID
+	printer *message.Printer
+}
+
+func (p *ShoppingLists) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(p.text)
+
+	items := make([]*linebot.QuickReplyButton, 0, maxShoppingListButtons+2)
+	for i, list := range p.lists {
+		if i >= maxShoppingListButtons {
+			break
+		}
+		label := list.Name
+		if list.ID == p.current {
+			label = "✔" + label
+		}
+		label = truncateLabel(label)
+		data := "Shopping#list#switch#" + string(list.ID)
+		items = append(items, &linebot.QuickReplyButton{
+			Action: linebot.NewPostbackAction(label, data, "", label, "", ""),
+		})
+	}
+	items = append(items, &linebot.QuickReplyButton{
+		Action: postbackAction(p.printer, "新規作成", "Shopping#list#add"),
+	})
+	if p.current != model.DefaultShoppingListID {
+		if current, err := p.lists.Get(p.current); err == nil {
+			label := truncateLabel(p.printer.Sprintf("「%s」を削除", current.Name))
+			data := "Shopping#list#delete#" + string(current.ID)
+			items = append(items, &linebot.QuickReplyButton{
+				Action: linebot.NewPostbackAction(label, data, "", label, "", ""),
+			})
+		}
+	}
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{Items: items})
+
+	return msg
+}
+
+// ShoppingSuggestions implements repository.MessageProvider.
+type ShoppingSuggestions struct {
+	text        string
+	suggestions model.Suggestions
+	printer     *message.Printer
+}
+
+func (p *ShoppingSuggestions) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(p.text)
+	if len(p.suggestions) == 0 {
+		return msg
+	}
+
+	items := make([]*linebot.QuickReplyButton, 0, maxSuggestionButtons+1)
+	for i, suggestion := range p.suggestions {
+		if i >= maxSuggestionButtons {
+			break
+		}
+		text := suggestion.Item.Label()
+		label := truncateLabel(text)
+		items = append(items, &linebot.QuickReplyButton{
+			Action: linebot.NewPostbackAction(label, "Shopping#usual#add#"+text, "", label, "", ""),
 		})
 	}
+	if len(items) > 1 {
+		items = append(items, &linebot.QuickReplyButton{
+			Action: postbackAction(p.printer, "全部追加", "Shopping#usual#addAll"),
+		})
+	}
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{Items: items})
 
 	return msg
 }
 
+// ShoppingDeleteChoices implements repository.MessageProvider.
+type ShoppingDeleteChoices struct {
+	text    string
+	items   model.ShoppingItems
+	printer *message.Printer
+}
+
+func (p *ShoppingDeleteChoices) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(p.text)
+
+	buttons := make([]*linebot.QuickReplyButton, 0, maxDeleteChoiceButtons+2)
+	ids := make([]string, 0, maxDeleteChoiceButtons)
+	for i, item := range p.items {
+		if i >= maxDeleteChoiceButtons {
+			break
+		}
+		label := truncateLabel(item.Label())
+		buttons = append(buttons, &linebot.QuickReplyButton{
+			Action: linebot.NewPostbackAction(label, "Shopping#delete#items#"+item.ID, "", p.printer.Sprintf("「%s」を削除", item.Label()), "", ""),
+		})
+		ids = append(ids, item.ID)
+	}
+	if len(ids) > 1 {
+		buttons = append(buttons, &linebot.QuickReplyButton{
+			Action: postbackAction(p.printer, "全部削除", "Shopping#delete#items#"+strings.Join(ids, ",")),
+		})
+	}
+	buttons = append(buttons, &linebot.QuickReplyButton{
+		Action: postbackAction(p.printer, "キャンセル", "Shopping#deleteCancel"),
+	})
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{Items: buttons})
+
+	return msg
+}
+
+type ShoppingListDeleteConfirmation struct {
+	text    string
+	listID  model.ShoppingListID
+	printer *message.Printer
+}
+
+func (c *ShoppingListDeleteConfirmation) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(c.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
+		Items: []*linebot.QuickReplyButton{
+			{Action: postbackAction(c.printer, "YES", "Shopping#list#delete#confirm#"+string(c.listID))},
+			{Action: postbackAction(c.printer, "NO", "Shopping#lists")},
+		},
+	})
+
+	return msg
+}
+
+// postbackAction returns the postback action which sends the label as the display text.
+func postbackAction(printer *message.Printer, label, data string) *linebot.PostbackAction {
+	label = printer.Sprintf(label)
+	return linebot.NewPostbackAction(label, data, "", label, "", "")
+}
+
+// cancelAction returns the postback action to leave the flow in progress.
+func cancelAction(printer *message.Printer) *linebot.PostbackAction {
+	return postbackAction(printer, "キャンセル", "Conversation#cancel")
+}
+
+// truncateLabel truncates the label to fit in a quick reply button.
+func truncateLabel(label string) string {
+	runes := []rune(label)
+	if len(runes) <= maxQuickReplyLabel {
+		return label
+	}
+	return string(runes[:maxQuickReplyLabel-1]) + "…"
+}
+
 // ShoppingMenu implements repository.MessageProvider.
 type ReminderMenu struct {
 	text      string
 	flex      *linebot.FlexContainer
 	replyType model.ReminderReplyType
+	printer   *message.Printer
 }
 
 func (r *ReminderMenu) ToMessage() linebot.SendingMessage {
@@ -160,7 +478,7 @@ func (r *ReminderMenu) ToMessage() linebot.SendingMessage {
 	default:
 		msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
 			Items: []*linebot.QuickReplyButton{
-				{Action: linebot.NewPostbackAction("追加", "Reminder#add", "", "追加", "", "")},
+				{Action: postbackAction(r.printer, "追加", "Reminder#add")},
 			},
 		})
 	}
@@ -169,19 +487,21 @@ func (r *ReminderMenu) ToMessage() linebot.SendingMessage {
 }
 
 type ReminderChoices struct {
-	text   string
-	labels []string
-	types  []model.ExecutorType
+	text    string
+	labels  []string
+	types   []model.ExecutorType
+	printer *message.Printer
 }
 
 func (r *ReminderChoices) ToMessage() linebot.SendingMessage {
-	items := make([]*linebot.QuickReplyButton, 0, len(r.labels))
+	items := make([]*linebot.QuickReplyButton, 0, len(r.labels)+1)
 	for i := range r.labels {
 		label := r.labels[i]
 		items = append(items, &linebot.QuickReplyButton{
 			Action: linebot.NewPostbackAction(label, "Reminder#add#"+r.types[i].String(), "", label, "", ""),
 		})
 	}
+	items = append(items, &linebot.QuickReplyButton{Action: cancelAction(r.printer)})
 
 	var msg linebot.SendingMessage
 	msg = linebot.NewTextMessage(r.text)
@@ -191,8 +511,9 @@ func (r *ReminderChoices) ToMessage() linebot.SendingMessage {
 }
 
 type TimePicker struct {
-	text string
-	data string
+	text    string
+	data    string
+	printer *message.Printer
 }
 
 func (p *TimePicker) ToMessage() linebot.SendingMessage {
@@ -200,7 +521,55 @@ func (p *TimePicker) ToMessage() linebot.SendingMessage {
 	msg = linebot.NewTextMessage(p.text)
 	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
 		Items: []*linebot.QuickReplyButton{
-			{Action: linebot.NewDatetimePickerAction("時刻設定", p.data, "time", "", "", "")},
+			{Action: linebot.NewDatetimePickerAction(p.printer.Sprintf("時刻設定"), p.data, "time", "", "", "")},
+			{Action: cancelAction(p.printer)},
+		},
+	})
+
+	return msg
+}
+
+type ReminderScheduleChoices struct {
+	text         string
+	executorType model.ExecutorType
+	printer      *message.Printer
+}
+
+func (r *ReminderScheduleChoices) ToMessage() linebot.SendingMessage {
+	prefix := "Reminder#add#" + r.executorType.String()
+
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(r.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
+		Items: []*linebot.QuickReplyButton{
+			{Action: postbackAction(r.printer, "1回だけ", prefix+"#once")},
+			{Action: postbackAction(r.printer, "毎日", prefix+"#repeat")},
+			{Action: cancelAction(r.printer)},
+		},
+	})
+
+	return msg
+}
+
+type DateTimePicker struct {
+	text    string
+	data    string
+	minTime time.Time
+	printer *message.Printer
+}
+
+func (p *DateTimePicker) ToMessage() linebot.SendingMessage {
+	var minTime string
+	if !p.minTime.IsZero() {
+		minTime = p.minTime.Format(datetimePickerLayout)
+	}
+
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(p.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
+		Items: []*linebot.QuickReplyButton{
+			{Action: linebot.NewDatetimePickerAction(p.printer.Sprintf("日時設定"), p.data, "datetime", minTime, "", minTime)},
+			{Action: cancelAction(p.printer)},
 		},
 	})
 
@@ -208,8 +577,9 @@ func (p *TimePicker) ToMessage() linebot.SendingMessage {
 }
 
 type ReminderDeleteConfirmation struct {
-	text string
-	data string
+	text    string
+	data    string
+	printer *message.Printer
 }
 
 func (c *ReminderDeleteConfirmation) ToMessage() linebot.SendingMessage {
@@ -217,14 +587,60 @@ func (c *ReminderDeleteConfirmation) ToMessage() linebot.SendingMessage {
 	msg = linebot.NewTextMessage(c.text)
 	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
 		Items: []*linebot.QuickReplyButton{
-			{Action: linebot.NewPostbackAction("YES", c.data, "", "YES", "", "")},
-			{Action: linebot.NewPostbackAction("NO", "Reminder#cancel", "", "NO", "", "")},
+			{Action: postbackAction(c.printer, "YES", c.data)},
+			{Action: postbackAction(c.printer, "NO", "Reminder#cancel")},
+		},
+	})
+
+	return msg
+}
+
+type ReminderAdded struct {
+	text     string
+	undoData string
+	printer  *message.Printer
+}
+
+func (r *ReminderAdded) ToMessage() linebot.SendingMessage {
+	var msg linebot.SendingMessage
+	msg = linebot.NewTextMessage(r.text)
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{
+		Items: []*linebot.QuickReplyButton{
+			{Action: postbackAction(r.printer, "取り消す", r.undoData)},
 		},
 	})
 
 	return msg
 }
 
+// quickReplier is a message provider with quick replies which ReminderActions keeps.
+type quickReplier interface {
+	quickReplies() []*linebot.QuickReplyButton
+}
+
+type ReminderActions struct {
+	provider repository.MessageProvider
+	itemID   model.ReminderItemID
+	printer  *message.Printer
+}
+
+func (r *ReminderActions) ToMessage() linebot.SendingMessage {
+	id := string(r.itemID)
+	items := []*linebot.QuickReplyButton{
+		{Action: postbackAction(r.printer, "10分後", "Reminder#snooze#10#"+id)},
+		{Action: postbackAction(r.printer, "1時間後", "Reminder#snooze#60#"+id)},
+		{Action: postbackAction(r.printer, "完了", "Reminder#ack#"+id)},
+	}
+	// the quick replies of the message such as the shopping menu follow them, LINE allows 13 items
+	if q, ok := r.provider.(quickReplier); ok {
+		items = append(items, q.quickReplies()...)
+	}
+	msg := r.provider.ToMessage()
+	msg = msg.WithQuickReplies(&linebot.QuickReplyItems{Items: items})
+
+	return msg
+}
+
 type Image struct {
 	originalURL string
 	previewURL  string
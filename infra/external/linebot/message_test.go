@@ -0,0 +1,36 @@
+package linebot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+func TestReminderActions_ToMessage(t *testing.T) {
+	t.Parallel()
+	set := NewMessageProviderSet()
+	msg := set.ReminderActions(set.ShoppingMenu("list", model.ShoppingReplyTypeAll), "item1").ToMessage()
+	b, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	var got struct {
+		QuickReply struct {
+			Items []struct {
+				Action struct {
+					Label string `json:"label"`
+				} `json:"action"`
+			} `json:"items"`
+		} `json:"quickReply"`
+	}
+	require.NoError(t, json.Unmarshal(b, &got))
+	labels := make([]string, 0, len(got.QuickReply.Items))
+	for _, item := range got.QuickReply.Items {
+		labels = append(labels, item.Action.Label)
+	}
+	// the quick replies of the shopping menu are kept
+	assert.Equal(t, []string{"10分後", "1時間後", "完了", "削除", "追加", "表示", "いつもの", "リスト切替"}, labels)
+}
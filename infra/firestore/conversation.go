@@ -20,6 +20,8 @@ func NewConversationStatus(src *model.ConversationStatus) *ConversationStatus {
 	return &ConversationStatus{
 		ConversationID: src.ConversationID,
 		Status:         int(src.Type),
+		Payload:        src.Payload,
+		UpdatedAt:      src.UpdatedAt,
 	}
 }
 
@@ -71,14 +73,78 @@ func (c *Conversation) GetStatus(ctx context.Context, conversationID model.Conve
 	return ret.Model(conversationID), nil
 }
 
+func (c *Conversation) setting(conversationID model.ConversationID) *firestore.DocumentRef {
+	return c.conversation(conversationID).Collection("settings").Doc("default")
+}
+
+func (c *Conversation) SetSetting(ctx context.Context, setting *model.ConversationSetting) error {
+	ctx, span := c.tracer.Start(ctx, "Conversation#SetSetting")
+	defer span.End()
+
+	entity := NewConversationSetting(setting)
+	if _, err := c.setting(setting.ConversationID).Set(ctx, entity); err != nil {
+		return xerrors.Errorf("failed to set conversation setting: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Conversation) GetSetting(ctx context.Context, conversationID model.ConversationID) (*model.ConversationSetting, error) {
+	ctx, span := c.tracer.Start(ctx, "Conversation#GetSetting")
+	defer span.End()
+
+	doc, err := c.setting(conversationID).Get(ctx)
+	if err != nil {
+		if gs.Code(err) == codes.NotFound {
+			return nil, code.With(err, code.NotFound)
+		}
+		return nil, xerrors.Errorf("failed to get conversation setting: %w", err)
+	}
+
+	var ret ConversationSetting
+	if err := doc.DataTo(&ret); err != nil {
+		return nil, xerrors.Errorf("failed to convert response as ConversationSetting: %w", err)
+	}
+	return ret.Model(conversationID), nil
+}
+
 type ConversationStatus struct {
 	ConversationID model.ConversationID `firestore:"-"`
 	Status         int                  `firestore:"status"`
+	Payload        string               `firestore:"payload,omitempty"`
+	UpdatedAt      int64                `firestore:"updated_at"`
 }
 
 func (c *ConversationStatus) Model(conversationID model.ConversationID) *model.ConversationStatus {
 	return &model.ConversationStatus{
 		ConversationID: conversationID,
 		Type:           model.ConversationStatusType(c.Status),
+		Payload:        c.Payload,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
+
+type ConversationSetting struct {
+	ConversationID model.ConversationID `firestore:"-"`
+	Timezone       string               `firestore:"timezone,omitempty"`
+	ShoppingListID string               `firestore:"shopping_list_id,omitempty"`
+	Language       string               `firestore:"language,omitempty"`
+}
+
+func NewConversationSetting(src *model.ConversationSetting) *ConversationSetting {
+	return &ConversationSetting{
+		ConversationID: src.ConversationID,
+		Timezone:       src.Timezone,
+		ShoppingListID: string(src.ShoppingListID),
+		Language:       string(src.Language),
+	}
+}
+
+func (c *ConversationSetting) Model(conversationID model.ConversationID) *model.ConversationSetting {
+	return &model.ConversationSetting{
+		ConversationID: conversationID,
+		Timezone:       c.Timezone,
+		ShoppingListID: model.ShoppingListID(c.ShoppingListID),
+		Language:       model.Language(c.Language),
 	}
 }
@@ -35,6 +35,16 @@ func TestConversation_SetStatus(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "set conversation type reminder add with payload",
+			status: &model.ConversationStatus{
+				ConversationID: "conv_set_reminder_add",
+				Type:           model.ConversationStatusTypeReminderAdd,
+				Payload:        "ゴミ出し",
+				UpdatedAt:      1754784000,
+			},
+			wantErr: nil,
+		},
 		{
 			name: "try to set invalid conversation type",
 			status: &model.ConversationStatus{
@@ -110,3 +120,33 @@ func TestConversation_GetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestConversation_Setting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+
+	_, err := conv.GetSetting(ctx, "conv_setting_not_found")
+	require.Equal(t, code.NotFound, code.From(err))
+
+	setting := &model.ConversationSetting{
+		ConversationID: "conv_setting",
+		Timezone:       "America/New_York",
+		ShoppingListID: "list_01",
+		Language:       model.LanguageEnglish,
+	}
+	require.NoError(t, conv.SetSetting(ctx, setting))
+	got, err := conv.GetSetting(ctx, setting.ConversationID)
+	require.NoError(t, err)
+	assert.Equal(t, setting, got)
+
+	// the setting is kept after the status is changed
+	status := &model.ConversationStatus{
+		ConversationID: setting.ConversationID,
+		Type:           model.ConversationStatusTypeNeutral,
+	}
+	require.NoError(t, conv.SetStatus(ctx, status))
+	got, err = conv.GetSetting(ctx, setting.ConversationID)
+	require.NoError(t, err)
+	assert.Equal(t, setting, got)
+}
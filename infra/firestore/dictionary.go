@@ -0,0 +1,83 @@
+package firestore
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+type Dictionary struct {
+	*Conversation
+}
+
+func NewDictionary(c *Conversation) *Dictionary {
+	return &Dictionary{Conversation: c}
+}
+
+func (d *Dictionary) userWords(conversationID model.ConversationID) *firestore.CollectionRef {
+	return d.conversation(conversationID).Collection("user_words")
+}
+
+func (d *Dictionary) AddWord(ctx context.Context, word *model.UserWord) error {
+	ctx, span := d.tracer.Start(ctx, "Dictionary#AddWord")
+	defer span.End()
+
+	if err := word.Validate(); err != nil {
+		return xerrors.Errorf("user word validation failed: %w", err)
+	}
+
+	// the surface is the document id to replace the word of the same surface
+	entity := NewUserWord(word)
+	if _, err := d.userWords(word.ConversationID).Doc(word.Surface).Set(ctx, entity); err != nil {
+		return xerrors.Errorf("failed to set user word: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Dictionary) FindWords(ctx context.Context, conversationID model.ConversationID) (model.UserWords, error) {
+	ctx, span := d.tracer.Start(ctx, "Dictionary#FindWords")
+	defer span.End()
+
+	docs, err := d.userWords(conversationID).OrderBy("created_at", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get all: %w", err)
+	}
+
+	words := make(model.UserWords, 0, len(docs))
+	for _, doc := range docs {
+		var word UserWord
+		if err := doc.DataTo(&word); err != nil {
+			return nil, xerrors.Errorf("failed to convert response as UserWord: %w", err)
+		}
+		words = append(words, word.Model(conversationID))
+	}
+
+	return words, nil
+}
+
+type UserWord struct {
+	Surface   string `firestore:"surface"`
+	Reading   string `firestore:"reading"`
+	CreatedAt int64  `firestore:"created_at"`
+}
+
+func NewUserWord(src *model.UserWord) *UserWord {
+	return &UserWord{
+		Surface:   src.Surface,
+		Reading:   src.Reading,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+func (c *UserWord) Model(conversationID model.ConversationID) *model.UserWord {
+	return &model.UserWord{
+		ConversationID: conversationID,
+		Surface:        c.Surface,
+		Reading:        c.Reading,
+		CreatedAt:      c.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package firestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+func TestDictionary_AddWord(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestDictionary_AddWord"
+	ctx := context.Background()
+	d := NewDictionary(NewConversation(testCli))
+
+	oikos, err := model.NewUserWord(conversationID, "オイコス", "オイコス", time.Unix(1666416720, 0))
+	require.NoError(t, err)
+	require.NoError(t, d.AddWord(ctx, oikos))
+	fuwa, err := model.NewUserWord(conversationID, "ふわとろ", "ふわとろ", time.Unix(1666416727, 0))
+	require.NoError(t, err)
+	require.NoError(t, d.AddWord(ctx, fuwa))
+
+	// the word of the same surface is replaced
+	fixed := *oikos
+	fixed.Reading = "オイコスー"
+	require.NoError(t, d.AddWord(ctx, &fixed))
+
+	got, err := d.FindWords(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, model.UserWords{&fixed, fuwa}, got)
+
+	require.Error(t, d.AddWord(ctx, &model.UserWord{ConversationID: conversationID}))
+}
@@ -23,6 +23,8 @@ var Set = wire.NewSet(
 	wire.Bind(new(repository.Shopping), new(*Shopping)),
 	NewReminder,
 	wire.Bind(new(repository.Reminder), new(*Reminder)),
+	NewDictionary,
+	wire.Bind(new(repository.Dictionary), new(*Dictionary)),
 )
 
 type Client struct {
@@ -36,8 +36,25 @@ func TestMain(m *testing.M) {
 	}
 	for _, id := range ids {
 		conversationID := model.ConversationID(id)
-		s := NewShopping(conv).shopping(conversationID)
-		if _, err := removeAllDocuments(bw, s.DocumentRefs(ctx)); err != nil {
+		shopping := NewShopping(conv)
+		listIDs, err := removeAllDocuments(bw, shopping.shoppingLists(conversationID).DocumentRefs(ctx))
+		if err != nil {
+			panic(err)
+		}
+		listIDs = append(listIDs, string(model.DefaultShoppingListID))
+		for _, listID := range listIDs {
+			s := shopping.shopping(conversationID, model.ShoppingListID(listID))
+			if _, err := removeAllDocuments(bw, s.DocumentRefs(ctx)); err != nil {
+				panic(err)
+			}
+		}
+		if _, err := removeAllDocuments(bw, shopping.history(conversationID).DocumentRefs(ctx)); err != nil {
+			panic(err)
+		}
+		if _, err := removeAllDocuments(bw, shopping.snapshots(conversationID).DocumentRefs(ctx)); err != nil {
+			panic(err)
+		}
+		if _, err := removeAllDocuments(bw, NewDictionary(conv).userWords(conversationID).DocumentRefs(ctx)); err != nil {
 			panic(err)
 		}
 		r := NewReminder(conv).reminder(conversationID)
@@ -3,6 +3,7 @@ package firestore
 import (
 	"context"
 	"errors"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"golang.org/x/xerrors"
@@ -14,6 +15,13 @@ import (
 	"github.com/ww24/linebot/internal/code"
 )
 
+const (
+	// deliveryRetention is the period in which a delivery is kept to skip the retries of its task.
+	deliveryRetention = 7 * 24 * time.Hour
+	// maxPrunedDeliveries limits the deletions in a transaction.
+	maxPrunedDeliveries = 100
+)
+
 type Reminder struct {
 	*Conversation
 }
@@ -94,6 +102,29 @@ func (r *Reminder) Get(ctx context.Context, conversationID model.ConversationID,
 	return m, nil
 }
 
+func (r *Reminder) Update(ctx context.Context, item *model.ReminderItem) error {
+	ctx, span := r.tracer.Start(ctx, "Reminder#Update")
+	defer span.End()
+
+	entity := NewReminderItem(item)
+	doc := r.reminder(item.ConversationID).Doc(entity.ID)
+	updates := []firestore.Update{
+		{Path: "scheduler", Value: entity.Scheduler},
+		{Path: "executor", Value: entity.Executor},
+		{Path: "paused", Value: entity.Paused},
+		{Path: "exclusion", Value: entity.Exclusion},
+		{Path: "revision", Value: entity.Revision},
+	}
+	if _, err := doc.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("failed to update reminder: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Reminder) Delete(ctx context.Context, conversationID model.ConversationID, id model.ReminderItemID) error {
 	ctx, span := r.tracer.Start(ctx, "Reminder#Delete")
 	defer span.End()
@@ -106,44 +137,143 @@ func (r *Reminder) Delete(ctx context.Context, conversationID model.Conversation
 	return nil
 }
 
-func (r *Reminder) ListAll(ctx context.Context) ([]*model.ReminderItem, error) {
-	ctx, span := r.tracer.Start(ctx, "Reminder#ListAll")
+func (r *Reminder) Acknowledge(ctx context.Context, conversationID model.ConversationID, id model.ReminderItemID, t time.Time) error {
+	ctx, span := r.tracer.Start(ctx, "Reminder#Acknowledge")
 	defer span.End()
 
-	// TODO: return wrapped iterator for performance
+	doc := r.reminder(conversationID).Doc(string(id))
+	updates := []firestore.Update{
+		{Path: "acknowledged_at", Value: t.Unix()},
+	}
+	if _, err := doc.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("failed to acknowledge reminder: %w", err)
+	}
 
-	items := make([]*model.ReminderItem, 0)
-	iter := r.conversations().Documents(ctx)
+	return nil
+}
+
+func (r *Reminder) deliveries(conversationID model.ConversationID) *firestore.CollectionRef {
+	return r.conversation(conversationID).Collection("deliveries")
+}
+
+func (r *Reminder) delivery(d *model.Delivery) *firestore.DocumentRef {
+	return r.deliveries(d.ConversationID).Doc(d.ID())
+}
+
+func (r *Reminder) BeginDelivery(ctx context.Context, d *model.Delivery) error {
+	ctx, span := r.tracer.Start(ctx, "Reminder#BeginDelivery")
+	defer span.End()
+
+	doc := r.delivery(d)
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		ss, err := tx.Get(doc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return xerrors.Errorf("failed to get delivery: %w", err)
+		}
+		if ss.Exists() {
+			var prev Delivery
+			if err := ss.DataTo(&prev); err != nil {
+				return xerrors.Errorf("failed to convert data to delivery: %w", err)
+			}
+			if prev.Model(d.ConversationID).Blocks(d.StartedAt) {
+				err := xerrors.Errorf("delivery %s is already %s", d.ID(), model.DeliveryOutcome(prev.Outcome))
+				return code.With(err, code.AlreadyExists)
+			}
+		}
+		if err := tx.Set(doc, NewDelivery(d)); err != nil {
+			return xerrors.Errorf("failed to set delivery: %w", err)
+		}
+		return nil
+	}
+	if err := r.cli.RunTransaction(ctx, txf); err != nil {
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reminder) FinishDelivery(ctx context.Context, d *model.Delivery) error {
+	ctx, span := r.tracer.Start(ctx, "Reminder#FinishDelivery")
+	defer span.End()
+
+	entity := NewDelivery(d)
+	item := r.reminder(d.ConversationID).Doc(string(d.ItemID))
+	// the deliveries of the conversation are pruned, otherwise they grow with every execution
+	expired := r.deliveries(d.ConversationID).
+		Where("scheduled_at", "<", d.ScheduledAt.Add(-deliveryRetention).Unix()).
+		Limit(maxPrunedDeliveries)
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		ss, err := tx.Get(item)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return xerrors.Errorf("failed to get reminder: %w", err)
+		}
+		olds, err := tx.Documents(expired).GetAll()
+		if err != nil {
+			return xerrors.Errorf("failed to get expired deliveries: %w", err)
+		}
+		for _, old := range olds {
+			if err := tx.Delete(old.Ref); err != nil {
+				return xerrors.Errorf("failed to delete delivery: %w", err)
+			}
+		}
+		if err := tx.Set(r.delivery(d), entity); err != nil {
+			return xerrors.Errorf("failed to set delivery: %w", err)
+		}
+		// the item may be deleted during the delivery
+		if !ss.Exists() {
+			return nil
+		}
+		updates := []firestore.Update{
+			{Path: "last_delivery", Value: entity},
+		}
+		if err := tx.Update(item, updates); err != nil {
+			return xerrors.Errorf("failed to update reminder: %w", err)
+		}
+		return nil
+	}
+	if err := r.cli.RunTransaction(ctx, txf); err != nil {
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reminder) ListAll(ctx context.Context, fn func(*model.ReminderItem) error) error {
+	ctx, span := r.tracer.Start(ctx, "Reminder#ListAll")
+	defer span.End()
+
+	// document paths are "conversations/{conversation_id}/reminders/{item_id}",
+	// so ordering by them groups the items by conversation
+	iter := r.cli.CollectionGroup("reminders").OrderBy(firestore.DocumentID, firestore.Asc).Documents(ctx)
+	defer iter.Stop()
 	for {
-		ss, err := iter.Next()
+		doc, err := iter.Next()
 		if errors.Is(err, iterator.Done) {
 			break
 		}
 		if err != nil {
-			return nil, xerrors.Errorf("failed to iterate conversations: %w", err)
+			return xerrors.Errorf("failed to iterate reminders: %w", err)
 		}
 
-		conversationID := model.ConversationID(ss.Ref.ID)
-		docs, err := ss.Ref.Collection("reminders").OrderBy("created_at", firestore.Asc).Documents(ctx).GetAll()
-		if err != nil {
-			return nil, xerrors.Errorf("failed to find reminder: %w", err)
+		var item ReminderItem
+		if err := doc.DataTo(&item); err != nil {
+			return xerrors.Errorf("failed to convert data to item: %w", err)
 		}
 
-		for _, doc := range docs {
-			var item ReminderItem
-			if err := doc.DataTo(&item); err != nil {
-				return nil, xerrors.Errorf("failed to convert data to item: %w", err)
-			}
-
-			m, err := item.Model(conversationID, doc.Ref.ID)
-			if err != nil {
-				return nil, err
-			}
-			items = append(items, m)
+		conversationID := model.ConversationID(doc.Ref.Parent.Parent.ID)
+		m, err := item.Model(conversationID, doc.Ref.ID)
+		if err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
 		}
 	}
 
-	return items, nil
+	return nil
 }
 
 type ReminderItem struct {
@@ -151,11 +281,18 @@ type ReminderItem struct {
 	ID             string               `firestore:"-"`
 	Scheduler      string               `firestore:"scheduler"`
 	Executor       *Executor            `firestore:"executor"`
+	SnoozedFrom    string               `firestore:"snoozed_from,omitempty"`
+	AcknowledgedAt int64                `firestore:"acknowledged_at,omitempty"` // UNIX time
+	Paused         bool                 `firestore:"paused,omitempty"`
+	Exclusion      *Exclusion           `firestore:"exclusion,omitempty"`
+	LastDelivery   *Delivery            `firestore:"last_delivery,omitempty"`
+	Revision       int                  `firestore:"revision,omitempty"`
 	CreatedAt      int64                `firestore:"created_at"` // UNIX time
 }
 
 type Executor struct {
-	Type model.ExecutorType `firestore:"type"`
+	Type    model.ExecutorType `firestore:"type"`
+	Payload string             `firestore:"payload,omitempty"`
 }
 
 func NewReminderItem(src *model.ReminderItem) *ReminderItem {
@@ -164,6 +301,11 @@ func NewReminderItem(src *model.ReminderItem) *ReminderItem {
 		ID:             string(src.ID),
 		Scheduler:      src.Scheduler.String(),
 		Executor:       NewExecutor(src.Executor),
+		SnoozedFrom:    string(src.SnoozedFrom),
+		AcknowledgedAt: src.AcknowledgedAt,
+		Paused:         src.Paused,
+		Exclusion:      NewExclusion(src.Exclusion),
+		Revision:       src.Revision,
 	}
 }
 
@@ -173,22 +315,116 @@ func (r *ReminderItem) Model(conversationID model.ConversationID, id string) (*m
 		return nil, xerrors.Errorf("failed to parse scheduler: %w", err)
 	}
 
+	exclusion, err := r.Exclusion.Model()
+	if err != nil {
+		return nil, err
+	}
+
 	return &model.ReminderItem{
 		ConversationID: conversationID,
 		ID:             model.ReminderItemID(id),
 		Scheduler:      sch,
 		Executor:       r.Executor.Model(),
+		SnoozedFrom:    model.ReminderItemID(r.SnoozedFrom),
+		AcknowledgedAt: r.AcknowledgedAt,
+		Paused:         r.Paused,
+		Exclusion:      exclusion,
+		LastDelivery:   r.LastDelivery.Model(conversationID),
+		Revision:       r.Revision,
 	}, nil
 }
 
 func NewExecutor(src *model.Executor) *Executor {
 	return &Executor{
-		Type: src.Type,
+		Type:    src.Type,
+		Payload: src.Payload,
 	}
 }
 
 func (e *Executor) Model() *model.Executor {
 	return &model.Executor{
-		Type: e.Type,
+		Type:    e.Type,
+		Payload: e.Payload,
+	}
+}
+
+type Exclusion struct {
+	Holidays     bool     `firestore:"holidays,omitempty"`
+	BusinessDays bool     `firestore:"business_days,omitempty"`
+	Ranges       []string `firestore:"ranges,omitempty"` // "2006-01-02/2006-01-02"
+}
+
+func NewExclusion(src *model.Exclusion) *Exclusion {
+	if src.Empty() {
+		return nil
+	}
+	ranges := make([]string, 0, len(src.Ranges))
+	for _, r := range src.Ranges {
+		ranges = append(ranges, r.String())
+	}
+	return &Exclusion{
+		Holidays:     src.Holidays,
+		BusinessDays: src.BusinessDays,
+		Ranges:       ranges,
+	}
+}
+
+func (e *Exclusion) Model() (*model.Exclusion, error) {
+	if e == nil {
+		return nil, nil
+	}
+	ranges := make([]*model.DateRange, 0, len(e.Ranges))
+	for _, s := range e.Ranges {
+		r, err := model.ParseDateRange(s)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to parse date range: %w", err)
+		}
+		ranges = append(ranges, r)
+	}
+	return &model.Exclusion{
+		Holidays:     e.Holidays,
+		BusinessDays: e.BusinessDays,
+		Ranges:       ranges,
+	}, nil
+}
+
+type Delivery struct {
+	ItemID      string `firestore:"item_id"`
+	ScheduledAt int64  `firestore:"scheduled_at"`      // UNIX time
+	StartedAt   int64  `firestore:"started_at"`        // UNIX time
+	SentAt      int64  `firestore:"sent_at,omitempty"` // UNIX time
+	Outcome     int    `firestore:"outcome"`
+	Error       string `firestore:"error,omitempty"`
+}
+
+func NewDelivery(src *model.Delivery) *Delivery {
+	d := &Delivery{
+		ItemID:      string(src.ItemID),
+		ScheduledAt: src.ScheduledAt.Unix(),
+		StartedAt:   src.StartedAt.Unix(),
+		Outcome:     int(src.Outcome),
+		Error:       src.Error,
+	}
+	if !src.SentAt.IsZero() {
+		d.SentAt = src.SentAt.Unix()
+	}
+	return d
+}
+
+func (d *Delivery) Model(conversationID model.ConversationID) *model.Delivery {
+	if d == nil {
+		return nil
+	}
+	m := &model.Delivery{
+		ConversationID: conversationID,
+		ItemID:         model.ReminderItemID(d.ItemID),
+		ScheduledAt:    time.Unix(d.ScheduledAt, 0),
+		StartedAt:      time.Unix(d.StartedAt, 0),
+		Outcome:        model.DeliveryOutcome(d.Outcome),
+		Error:          d.Error,
+	}
+	if d.SentAt != 0 {
+		m.SentAt = time.Unix(d.SentAt, 0)
 	}
+	return m
 }
@@ -2,12 +2,16 @@ package firestore
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/internal/code"
@@ -51,6 +55,92 @@ func TestReminder_Add(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "add a message item",
+			item: &model.ReminderItem{
+				ID:             "item_02",
+				ConversationID: conversationID,
+				Scheduler: &model.OneshotScheduler{
+					Time: time.Unix(1666416727, 0),
+				},
+				Executor: &model.Executor{
+					Type:    model.ExecutorTypeMessage,
+					Payload: "ゴミ出し",
+				},
+			},
+			want: &ReminderItem{
+				Scheduler: (&model.OneshotScheduler{
+					Time: time.Unix(1666416727, 0),
+				}).String(),
+				Executor: &Executor{
+					Type:    model.ExecutorTypeMessage,
+					Payload: "ゴミ出し",
+				},
+				CreatedAt: testTime.Unix(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "add a snoozed item",
+			item: &model.ReminderItem{
+				ID:             "item_03",
+				ConversationID: conversationID,
+				Scheduler: &model.OneshotScheduler{
+					Time: time.Unix(1666416727, 0),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				SnoozedFrom: "item_01",
+			},
+			want: &ReminderItem{
+				Scheduler: (&model.OneshotScheduler{
+					Time: time.Unix(1666416727, 0),
+				}).String(),
+				Executor: &Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				SnoozedFrom: "item_01",
+				CreatedAt:   testTime.Unix(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "add an item with exclusion",
+			item: &model.ReminderItem{
+				ID:             "item_04",
+				ConversationID: conversationID,
+				Scheduler: &model.DailyScheduler{
+					Time: time.Unix(1666416727, 0),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				Exclusion: &model.Exclusion{
+					Holidays: true,
+					Ranges: []*model.DateRange{
+						{
+							Start: time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC),
+							End:   time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			want: &ReminderItem{
+				Scheduler: (&model.DailyScheduler{
+					Time: time.Unix(1666416727, 0),
+				}).String(),
+				Executor: &Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				Exclusion: &Exclusion{
+					Holidays: true,
+					Ranges:   []string{"2025-08-10/2025-08-15"},
+				},
+				CreatedAt: testTime.Unix(),
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -255,6 +345,71 @@ func TestReminder_Delete(t *testing.T) {
 	}
 }
 
+func TestReminder_Update(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestReminder_Update"
+	conv := NewConversation(testCli)
+	r := NewReminder(conv)
+	ctx := context.Background()
+	data := &model.ReminderItem{
+		ID:             "item_01",
+		ConversationID: conversationID,
+		Scheduler: &model.OneshotScheduler{
+			Time: time.Unix(1666416727, 0).In(time.UTC),
+		},
+		Executor: &model.Executor{
+			Type: model.ExecutorTypeShoppingList,
+		},
+	}
+	require.NoError(t, r.Add(ctx, data))
+	tests := []struct {
+		name     string
+		item     *model.ReminderItem
+		wantCode code.Code
+	}{
+		{
+			name: "update an item",
+			item: &model.ReminderItem{
+				ID:             "item_01",
+				ConversationID: conversationID,
+				Scheduler: &model.OneshotScheduler{
+					Time: time.Unix(1666416787, 0).In(time.UTC),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+				Paused: true,
+			},
+			wantCode: code.OK,
+		},
+		{
+			name: "not found",
+			item: &model.ReminderItem{
+				ID:             "not_found_id",
+				ConversationID: conversationID,
+				Scheduler:      data.Scheduler,
+				Executor:       data.Executor,
+			},
+			wantCode: code.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := r.Update(ctx, tt.item)
+			require.Equal(t, tt.wantCode, code.From(err))
+			if err != nil {
+				return
+			}
+
+			got, err := r.Get(ctx, conversationID, tt.item.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.item, got)
+		})
+	}
+}
+
 func TestReminder_ListAll(t *testing.T) {
 	t.Parallel()
 	const conversationIDPrefix = "TestReminder_ListAll_"
@@ -286,24 +441,132 @@ func TestReminder_ListAll(t *testing.T) {
 	for _, d := range data {
 		require.NoError(t, r.Add(ctx, d))
 	}
+	errStop := errors.New("stop")
 	tests := []struct {
 		name    string
+		stopAt  int
 		want    []*model.ReminderItem
 		wantErr error
-	}{}
+	}{
+		{
+			name: "all items",
+			want: data,
+		},
+		{
+			name:    "stop by callback",
+			stopAt:  1,
+			want:    data[:1],
+			wantErr: errStop,
+		},
+	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			reminders, err := r.ListAll(ctx)
-			require.ErrorIs(t, err, tt.wantErr)
 			got := make([]*model.ReminderItem, 0, len(tt.want))
-			for _, reminder := range reminders {
-				if strings.HasPrefix(string(reminder.ConversationID), conversationIDPrefix) {
-					got = append(got, reminder)
+			err := r.ListAll(ctx, func(item *model.ReminderItem) error {
+				if !strings.HasPrefix(string(item.ConversationID), conversationIDPrefix) {
+					return nil
 				}
-			}
+				if tt.stopAt > 0 && len(got) == tt.stopAt {
+					return errStop
+				}
+				got = append(got, item)
+				return nil
+			})
+			require.ErrorIs(t, err, tt.wantErr)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func BenchmarkReminder_ListAll(b *testing.B) {
+	const (
+		conversationIDPrefix = "BenchmarkReminder_ListAll_"
+		conversations        = 50
+		itemsPerConversation = 10
+	)
+	conv := NewConversation(testCli)
+	r := NewReminder(conv)
+	ctx := context.Background()
+	bw := testCli.cli.BulkWriter(ctx)
+	for i := 0; i < conversations; i++ {
+		conversationID := model.ConversationID(fmt.Sprintf("%s%03d", conversationIDPrefix, i))
+		for j := 0; j < itemsPerConversation; j++ {
+			entity := NewReminderItem(&model.ReminderItem{
+				ID:             model.ReminderItemID(fmt.Sprintf("item_%03d", j)),
+				ConversationID: conversationID,
+				Scheduler: &model.DailyScheduler{
+					Time: time.Unix(1666416727, 0),
+				},
+				Executor: &model.Executor{
+					Type: model.ExecutorTypeShoppingList,
+				},
+			})
+			if _, err := bw.Set(r.reminder(conversationID).Doc(entity.ID), entity); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	bw.End()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		err := r.ListAll(ctx, func(*model.ReminderItem) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count < conversations*itemsPerConversation {
+			b.Fatalf("unexpected count: %d", count)
+		}
+	}
+}
+
+func TestReminder_Delivery(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestReminder_Delivery"
+	conv := NewConversation(testCli)
+	r := NewReminder(conv)
+	ctx := context.Background()
+	item := &model.ReminderItem{
+		ID:             "item_01",
+		ConversationID: conversationID,
+		Scheduler: &model.OneshotScheduler{
+			Time: time.Unix(1666416727, 0).In(time.UTC),
+		},
+		Executor: &model.Executor{
+			Type: model.ExecutorTypeShoppingList,
+		},
+	}
+	require.NoError(t, r.Add(ctx, item))
+
+	now := time.Unix(1666416728, 0)
+	d := model.NewDelivery(item, time.Unix(1666416727, 0), now)
+	require.NoError(t, r.BeginDelivery(ctx, d))
+	// a retry while the first delivery is in progress
+	err := r.BeginDelivery(ctx, model.NewDelivery(item, d.ScheduledAt, now.Add(time.Second)))
+	require.Equal(t, code.AlreadyExists, code.From(err))
+
+	d.Finish(now.Add(time.Second), nil)
+	require.NoError(t, r.FinishDelivery(ctx, d))
+	err = r.BeginDelivery(ctx, model.NewDelivery(item, d.ScheduledAt, now.Add(time.Hour)))
+	require.Equal(t, code.AlreadyExists, code.From(err))
+
+	got, err := r.Get(ctx, conversationID, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, d, got.LastDelivery)
+
+	// a later delivery prunes the expired one
+	later := model.NewDelivery(item, d.ScheduledAt.Add(deliveryRetention+time.Hour), now.Add(deliveryRetention+time.Hour))
+	require.NoError(t, r.BeginDelivery(ctx, later))
+	later.Finish(later.StartedAt.Add(time.Second), nil)
+	require.NoError(t, r.FinishDelivery(ctx, later))
+	_, err = r.delivery(d).Get(ctx)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = r.delivery(later).Get(ctx)
+	require.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package firestore
 
 import (
 	"context"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"golang.org/x/xerrors"
@@ -9,6 +10,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/code"
 )
 
 type Shopping struct {
@@ -19,8 +21,17 @@ func NewShopping(c *Conversation) *Shopping {
 	return &Shopping{Conversation: c}
 }
 
-func (s *Shopping) shopping(conversationID model.ConversationID) *firestore.CollectionRef {
-	return s.conversation(conversationID).Collection("shoppings")
+func (s *Shopping) shoppingLists(conversationID model.ConversationID) *firestore.CollectionRef {
+	return s.conversation(conversationID).Collection("shopping_lists")
+}
+
+// shopping returns the collection of items in the list,
+// items of the default list are stored in the collection which exists before named lists.
+func (s *Shopping) shopping(conversationID model.ConversationID, listID model.ShoppingListID) *firestore.CollectionRef {
+	if listID == model.DefaultShoppingListID {
+		return s.conversation(conversationID).Collection("shoppings")
+	}
+	return s.shoppingLists(conversationID).Doc(string(listID)).Collection("items")
 }
 
 func (s *Shopping) Add(ctx context.Context, items ...*model.ShoppingItem) error {
@@ -34,7 +45,7 @@ func (s *Shopping) Add(ctx context.Context, items ...*model.ShoppingItem) error
 			}
 
 			entity := NewShoppingItem(item)
-			shopping := s.shopping(item.ConversationID)
+			shopping := s.shopping(item.ConversationID, item.ListID)
 			if err := tx.Create(shopping.Doc(entity.ID), entity); err != nil {
 				return xerrors.Errorf("failed to create: %w", err)
 			}
@@ -48,11 +59,11 @@ func (s *Shopping) Add(ctx context.Context, items ...*model.ShoppingItem) error
 	return nil
 }
 
-func (s *Shopping) Find(ctx context.Context, conversationID model.ConversationID) ([]*model.ShoppingItem, error) {
+func (s *Shopping) Find(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) ([]*model.ShoppingItem, error) {
 	ctx, span := s.tracer.Start(ctx, "Shopping#Find")
 	defer span.End()
 
-	iter := s.shopping(conversationID).
+	iter := s.shopping(conversationID, listID).
 		OrderBy("created_at", firestore.Asc).
 		OrderBy("order", firestore.Asc).
 		Documents(ctx)
@@ -67,19 +78,85 @@ func (s *Shopping) Find(ctx context.Context, conversationID model.ConversationID
 		if err := doc.DataTo(&item); err != nil {
 			return nil, xerrors.Errorf("failed to convert response as ShoppingItem: %w", err)
 		}
-		items = append(items, item.Model(conversationID, doc.Ref.ID))
+		items = append(items, item.Model(conversationID, listID, doc.Ref.ID))
 	}
 
 	return items, nil
 }
 
-func (s *Shopping) BatchDelete(ctx context.Context, conversationID model.ConversationID, ids []string) error {
+func (s *Shopping) AddQuantity(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, id string, quantity int) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#AddQuantity")
+	defer span.End()
+
+	updates := []firestore.Update{
+		{Path: "quantity", Value: firestore.Increment(quantity)},
+	}
+	if _, err := s.shopping(conversationID, listID).Doc(id).Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("failed to add quantity: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) Check(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string, t time.Time) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#Check")
+	defer span.End()
+
+	updates := []firestore.Update{
+		{Path: "checked_at", Value: t.Unix()},
+	}
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, id := range ids {
+			if err := tx.Update(s.shopping(conversationID, listID).Doc(id), updates); err != nil {
+				return xerrors.Errorf("failed to update document: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := s.cli.RunTransaction(ctx, txf); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) Update(ctx context.Context, item *model.ShoppingItem) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#Update")
+	defer span.End()
+
+	if err := item.Validate(); err != nil {
+		return xerrors.Errorf("shopping item validation failed: %w", err)
+	}
+
+	updates := []firestore.Update{
+		{Path: "name", Value: item.Name},
+		{Path: "quantity", Value: item.Quantity},
+		{Path: "unit", Value: item.Unit},
+		{Path: "category", Value: item.Category},
+	}
+	if _, err := s.shopping(item.ConversationID, item.ListID).Doc(item.ID).Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("failed to update shopping item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) BatchDelete(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) error {
 	ctx, span := s.tracer.Start(ctx, "Shopping#BatchDelete")
 	defer span.End()
 
 	txf := func(ctx context.Context, tx *firestore.Transaction) error {
 		for _, id := range ids {
-			item := s.shopping(conversationID).Doc(id)
+			item := s.shopping(conversationID, listID).Doc(id)
 			if err := tx.Delete(item, firestore.Exists); err != nil {
 				return xerrors.Errorf("failed to delete document: %w", err)
 			}
@@ -95,11 +172,11 @@ func (s *Shopping) BatchDelete(ctx context.Context, conversationID model.Convers
 	return nil
 }
 
-func (s *Shopping) DeleteAll(ctx context.Context, conversationID model.ConversationID) error {
+func (s *Shopping) DeleteAll(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error {
 	ctx, span := s.tracer.Start(ctx, "Shopping#DeleteAll")
 	defer span.End()
 
-	refs, err := s.shopping(conversationID).DocumentRefs(ctx).GetAll()
+	refs, err := s.shopping(conversationID, listID).DocumentRefs(ctx).GetAll()
 	if err != nil {
 		return xerrors.Errorf("failed to get document refs: %w", err)
 	}
@@ -122,13 +199,238 @@ func (s *Shopping) DeleteAll(ctx context.Context, conversationID model.Conversat
 	return nil
 }
 
+func (s *Shopping) AddList(ctx context.Context, list *model.ShoppingList) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#AddList")
+	defer span.End()
+
+	entity := NewShoppingList(list)
+	if _, err := s.shoppingLists(list.ConversationID).Doc(entity.ID).Create(ctx, entity); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			err = code.With(err, code.AlreadyExists)
+		}
+		return xerrors.Errorf("failed to add shopping list: %w", err)
+	}
+
+	return nil
+}
+
+// FindLists returns the named lists, the default list is not included.
+func (s *Shopping) FindLists(ctx context.Context, conversationID model.ConversationID) ([]*model.ShoppingList, error) {
+	ctx, span := s.tracer.Start(ctx, "Shopping#FindLists")
+	defer span.End()
+
+	docs, err := s.shoppingLists(conversationID).OrderBy("created_at", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get all: %w", err)
+	}
+
+	lists := make([]*model.ShoppingList, 0, len(docs))
+	for _, doc := range docs {
+		var list ShoppingList
+		if err := doc.DataTo(&list); err != nil {
+			return nil, xerrors.Errorf("failed to convert response as ShoppingList: %w", err)
+		}
+		lists = append(lists, list.Model(conversationID, doc.Ref.ID))
+	}
+
+	return lists, nil
+}
+
+func (s *Shopping) UpdateList(ctx context.Context, list *model.ShoppingList) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#UpdateList")
+	defer span.End()
+
+	updates := []firestore.Update{
+		{Path: "name", Value: list.Name},
+	}
+	if _, err := s.shoppingLists(list.ConversationID).Doc(string(list.ID)).Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("failed to update shopping list: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteList deletes the list and its items.
+func (s *Shopping) DeleteList(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#DeleteList")
+	defer span.End()
+
+	refs, err := s.shopping(conversationID, listID).DocumentRefs(ctx).GetAll()
+	if err != nil {
+		return xerrors.Errorf("failed to get document refs: %w", err)
+	}
+
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, ref := range refs {
+			if err := tx.Delete(ref); err != nil {
+				return xerrors.Errorf("failed to delete document: %w", err)
+			}
+		}
+		if err := tx.Delete(s.shoppingLists(conversationID).Doc(string(listID)), firestore.Exists); err != nil {
+			return xerrors.Errorf("failed to delete document: %w", err)
+		}
+		return nil
+	}
+	if err := s.cli.RunTransaction(ctx, txf); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) history(conversationID model.ConversationID) *firestore.CollectionRef {
+	return s.conversation(conversationID).Collection("purchase_history")
+}
+
+func (s *Shopping) AppendHistory(ctx context.Context, records ...*model.PurchaseRecord) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#AppendHistory")
+	defer span.End()
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, record := range records {
+			entity := NewPurchaseRecord(record)
+			if err := tx.Create(s.history(record.ConversationID).Doc(entity.ID), entity); err != nil {
+				return xerrors.Errorf("failed to create: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := s.cli.RunTransaction(ctx, txf); err != nil {
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) FindHistory(ctx context.Context, conversationID model.ConversationID, since time.Time) ([]*model.PurchaseRecord, error) {
+	ctx, span := s.tracer.Start(ctx, "Shopping#FindHistory")
+	defer span.End()
+
+	docs, err := s.history(conversationID).
+		Where("at", ">=", since.Unix()).
+		OrderBy("at", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get all: %w", err)
+	}
+
+	records := make([]*model.PurchaseRecord, 0, len(docs))
+	for _, doc := range docs {
+		var record PurchaseRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, xerrors.Errorf("failed to convert response as PurchaseRecord: %w", err)
+		}
+		records = append(records, record.Model(conversationID, doc.Ref.ID))
+	}
+
+	return records, nil
+}
+
+func (s *Shopping) snapshots(conversationID model.ConversationID) *firestore.CollectionRef {
+	return s.conversation(conversationID).Collection("shopping_snapshots")
+}
+
+func (s *Shopping) SaveSnapshot(ctx context.Context, snapshot *model.ShoppingSnapshot) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#SaveSnapshot")
+	defer span.End()
+
+	col := s.snapshots(snapshot.ConversationID)
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		refs, err := tx.DocumentRefs(col).GetAll()
+		if err != nil {
+			return xerrors.Errorf("failed to get document refs: %w", err)
+		}
+		for _, ref := range refs {
+			if err := tx.Delete(ref); err != nil {
+				return xerrors.Errorf("failed to delete document: %w", err)
+			}
+		}
+		if err := tx.Set(col.Doc(string(snapshot.ID)), NewShoppingSnapshot(snapshot)); err != nil {
+			return xerrors.Errorf("failed to set document: %w", err)
+		}
+		return nil
+	}
+	if err := s.cli.RunTransaction(ctx, txf); err != nil {
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Shopping) GetSnapshot(ctx context.Context, conversationID model.ConversationID, id model.ShoppingSnapshotID) (*model.ShoppingSnapshot, error) {
+	ctx, span := s.tracer.Start(ctx, "Shopping#GetSnapshot")
+	defer span.End()
+
+	ss, err := s.snapshots(conversationID).Doc(string(id)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return nil, xerrors.Errorf("failed to get snapshot: %w", err)
+	}
+	var snapshot ShoppingSnapshot
+	if err := ss.DataTo(&snapshot); err != nil {
+		return nil, xerrors.Errorf("failed to convert response as ShoppingSnapshot: %w", err)
+	}
+
+	return snapshot.Model(conversationID, ss.Ref.ID), nil
+}
+
+func (s *Shopping) RestoreSnapshot(ctx context.Context, conversationID model.ConversationID, id model.ShoppingSnapshotID) error {
+	ctx, span := s.tracer.Start(ctx, "Shopping#RestoreSnapshot")
+	defer span.End()
+
+	doc := s.snapshots(conversationID).Doc(string(id))
+	txf := func(ctx context.Context, tx *firestore.Transaction) error {
+		ss, err := tx.Get(doc)
+		if err != nil {
+			return xerrors.Errorf("failed to get snapshot: %w", err)
+		}
+		var snapshot ShoppingSnapshot
+		if err := ss.DataTo(&snapshot); err != nil {
+			return xerrors.Errorf("failed to convert response as ShoppingSnapshot: %w", err)
+		}
+		shopping := s.shopping(conversationID, model.ShoppingListID(snapshot.ListID))
+		for _, item := range snapshot.Items {
+			if err := tx.Set(shopping.Doc(item.ID), item.Item); err != nil {
+				return xerrors.Errorf("failed to set document: %w", err)
+			}
+		}
+		if err := tx.Delete(doc); err != nil {
+			return xerrors.Errorf("failed to delete document: %w", err)
+		}
+		return nil
+	}
+	if err := s.cli.RunTransaction(ctx, txf); err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = code.With(err, code.NotFound)
+		}
+		return xerrors.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
 type ShoppingItem struct {
 	ConversationID model.ConversationID `firestore:"-"`
 	ID             string               `firestore:"-"`
 	Name           string               `firestore:"name"`
 	Quantity       int                  `firestore:"quantity"`
+	Unit           string               `firestore:"unit,omitempty"`
+	Category       string               `firestore:"category,omitempty"`
 	CreatedAt      int64                `firestore:"created_at"`
 	Order          int                  `firestore:"order"`
+	CheckedAt      int64                `firestore:"checked_at,omitempty"`
 }
 
 func NewShoppingItem(src *model.ShoppingItem) *ShoppingItem {
@@ -137,18 +439,124 @@ func NewShoppingItem(src *model.ShoppingItem) *ShoppingItem {
 		ID:             src.ID,
 		Name:           src.Name,
 		Quantity:       src.Quantity,
+		Unit:           src.Unit,
+		Category:       src.Category,
 		CreatedAt:      src.CreatedAt,
 		Order:          src.Order,
+		CheckedAt:      src.CheckedAt,
 	}
 }
 
-func (c *ShoppingItem) Model(conversationID model.ConversationID, id string) *model.ShoppingItem {
+func (c *ShoppingItem) Model(conversationID model.ConversationID, listID model.ShoppingListID, id string) *model.ShoppingItem {
 	return &model.ShoppingItem{
 		ConversationID: conversationID,
+		ListID:         listID,
 		ID:             id,
 		Name:           c.Name,
 		Quantity:       c.Quantity,
+		Unit:           c.Unit,
+		Category:       c.Category,
 		CreatedAt:      c.CreatedAt,
 		Order:          c.Order,
+		CheckedAt:      c.CheckedAt,
+	}
+}
+
+type ShoppingList struct {
+	ID        string `firestore:"-"`
+	Name      string `firestore:"name"`
+	CreatedAt int64  `firestore:"created_at"`
+}
+
+func NewShoppingList(src *model.ShoppingList) *ShoppingList {
+	return &ShoppingList{
+		ID:        string(src.ID),
+		Name:      src.Name,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+func (c *ShoppingList) Model(conversationID model.ConversationID, id string) *model.ShoppingList {
+	return &model.ShoppingList{
+		ID:             model.ShoppingListID(id),
+		ConversationID: conversationID,
+		Name:           c.Name,
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+type PurchaseRecord struct {
+	ID       string                  `firestore:"-"`
+	Name     string                  `firestore:"name"`
+	Quantity int                     `firestore:"quantity"`
+	Unit     string                  `firestore:"unit,omitempty"`
+	Category string                  `firestore:"category,omitempty"`
+	Event    model.PurchaseEventType `firestore:"event"`
+	At       int64                   `firestore:"at"`
+}
+
+func NewPurchaseRecord(src *model.PurchaseRecord) *PurchaseRecord {
+	return &PurchaseRecord{
+		ID:       src.ID,
+		Name:     src.Name,
+		Quantity: src.Quantity,
+		Unit:     src.Unit,
+		Category: src.Category,
+		Event:    src.Event,
+		At:       src.At,
+	}
+}
+
+func (c *PurchaseRecord) Model(conversationID model.ConversationID, id string) *model.PurchaseRecord {
+	return &model.PurchaseRecord{
+		ID:             id,
+		ConversationID: conversationID,
+		Name:           c.Name,
+		Quantity:       c.Quantity,
+		Unit:           c.Unit,
+		Category:       c.Category,
+		Event:          c.Event,
+		At:             c.At,
+	}
+}
+
+type ShoppingSnapshot struct {
+	ListID    string                  `firestore:"list_id,omitempty"`
+	Items     []*ShoppingSnapshotItem `firestore:"items"`
+	CreatedAt int64                   `firestore:"created_at"`
+}
+
+type ShoppingSnapshotItem struct {
+	ID   string        `firestore:"id"`
+	Item *ShoppingItem `firestore:"item"`
+}
+
+func NewShoppingSnapshot(src *model.ShoppingSnapshot) *ShoppingSnapshot {
+	items := make([]*ShoppingSnapshotItem, 0, len(src.Items))
+	for _, item := range src.Items {
+		items = append(items, &ShoppingSnapshotItem{
+			ID:   item.ID,
+			Item: NewShoppingItem(item),
+		})
+	}
+	return &ShoppingSnapshot{
+		ListID:    string(src.ListID),
+		Items:     items,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+func (c *ShoppingSnapshot) Model(conversationID model.ConversationID, id string) *model.ShoppingSnapshot {
+	listID := model.ShoppingListID(c.ListID)
+	items := make(model.ShoppingItems, 0, len(c.Items))
+	for _, item := range c.Items {
+		items = append(items, item.Item.Model(conversationID, listID, item.ID))
+	}
+	return &model.ShoppingSnapshot{
+		ID:             model.ShoppingSnapshotID(id),
+		ConversationID: conversationID,
+		ListID:         listID,
+		Items:          items,
+		CreatedAt:      c.CreatedAt,
 	}
 }
@@ -3,11 +3,13 @@ package firestore
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/code"
 )
 
 func TestShopping_Add(t *testing.T) {
@@ -120,7 +122,7 @@ func TestShopping_Add(t *testing.T) {
 			err := s.Add(ctx, tt.items...)
 			require.ErrorIs(t, err, tt.wantErr)
 
-			ss, err := s.shopping(tt.items[0].ConversationID).Documents(ctx).GetAll()
+			ss, err := s.shopping(tt.items[0].ConversationID, model.DefaultShoppingListID).Documents(ctx).GetAll()
 			require.NoError(t, err)
 			got := make([]*ShoppingItem, 0, len(ss))
 			for _, doc := range ss {
@@ -199,7 +201,7 @@ func TestShopping_Find(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := s.Find(ctx, tt.conversationID)
+			got, err := s.Find(ctx, tt.conversationID, model.DefaultShoppingListID)
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
@@ -252,7 +254,7 @@ func TestShopping_BatchDelete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := s.BatchDelete(ctx, conversationID, tt.ids)
+			err := s.BatchDelete(ctx, conversationID, model.DefaultShoppingListID, tt.ids)
 			require.ErrorIs(t, err, tt.wantErr)
 		})
 	}
@@ -304,8 +306,218 @@ func TestShopping_DeleteAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := s.DeleteAll(ctx, tt.conversationID)
+			err := s.DeleteAll(ctx, tt.conversationID, model.DefaultShoppingListID)
 			require.ErrorIs(t, err, tt.wantErr)
 		})
 	}
 }
+
+func TestShopping_AddQuantity(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_AddQuantity"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+	data := &model.ShoppingItem{
+		ID:             "item_01",
+		Name:           "卵",
+		Quantity:       1,
+		Unit:           "パック",
+		ConversationID: conversationID,
+		CreatedAt:      1666416720,
+		Order:          0,
+	}
+	require.NoError(t, s.Add(ctx, data))
+
+	require.NoError(t, s.AddQuantity(ctx, conversationID, model.DefaultShoppingListID, "item_01", 2))
+	err := s.AddQuantity(ctx, conversationID, model.DefaultShoppingListID, "not_found_id", 1)
+	require.Equal(t, code.NotFound, code.From(err))
+
+	got, err := s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	want := *data
+	want.Quantity = 3
+	assert.Equal(t, []*model.ShoppingItem{&want}, got)
+}
+
+func TestShopping_Update(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_Update"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+	data := &model.ShoppingItem{
+		ID:             "item_01",
+		Name:           "バター",
+		Quantity:       1,
+		ConversationID: conversationID,
+		CreatedAt:      1666416720,
+		Order:          0,
+	}
+	require.NoError(t, s.Add(ctx, data))
+
+	want := *data
+	want.Name = "豆乳"
+	want.Quantity = 2
+	want.Unit = "本"
+	want.Category = "乳製品・卵"
+	require.NoError(t, s.Update(ctx, &want))
+	notFound := want
+	notFound.ID = "not_found_id"
+	err := s.Update(ctx, &notFound)
+	require.Equal(t, code.NotFound, code.From(err))
+
+	got, err := s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingItem{&want}, got)
+}
+
+func TestShopping_Check(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_Check"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+	data := []*model.ShoppingItem{
+		{
+			ID:             "item_01",
+			Name:           "item 01",
+			Quantity:       1,
+			ConversationID: conversationID,
+			CreatedAt:      1666416720,
+			Order:          0,
+		},
+		{
+			ID:             "item_02",
+			Name:           "item 02",
+			Quantity:       1,
+			ConversationID: conversationID,
+			CreatedAt:      1666416720,
+			Order:          1,
+		},
+	}
+	require.NoError(t, s.Add(ctx, data...))
+
+	require.NoError(t, s.Check(ctx, conversationID, model.DefaultShoppingListID, []string{"item_02"}, time.Unix(1666416727, 0)))
+	err := s.Check(ctx, conversationID, model.DefaultShoppingListID, []string{"not_found_id"}, time.Unix(1666416727, 0))
+	require.Equal(t, code.NotFound, code.From(err))
+
+	got, err := s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	want := *data[1]
+	want.CheckedAt = 1666416727
+	assert.Equal(t, []*model.ShoppingItem{data[0], &want}, got)
+}
+
+func TestShopping_List(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_List"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+
+	groceries := &model.ShoppingList{ID: "list_01", ConversationID: conversationID, Name: "食料品", CreatedAt: 1666416720}
+	daily := &model.ShoppingList{ID: "list_02", ConversationID: conversationID, Name: "日用品", CreatedAt: 1666416727}
+	require.NoError(t, s.AddList(ctx, daily))
+	require.NoError(t, s.AddList(ctx, groceries))
+	err := s.AddList(ctx, groceries)
+	require.Equal(t, code.AlreadyExists, code.From(err))
+
+	got, err := s.FindLists(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingList{groceries, daily}, got)
+
+	// items are stored per list
+	items := []*model.ShoppingItem{
+		{ID: "item_01", Name: "牛乳", Quantity: 1, ConversationID: conversationID, CreatedAt: 1666416720},
+		{ID: "item_01", Name: "洗剤", Quantity: 1, ConversationID: conversationID, ListID: daily.ID, CreatedAt: 1666416720},
+	}
+	require.NoError(t, s.Add(ctx, items...))
+	gotItems, err := s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingItem{items[0]}, gotItems)
+	gotItems, err = s.Find(ctx, conversationID, daily.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingItem{items[1]}, gotItems)
+
+	renamed := *daily
+	renamed.Name = "雑貨"
+	require.NoError(t, s.UpdateList(ctx, &renamed))
+	err = s.UpdateList(ctx, &model.ShoppingList{ID: "not_found", ConversationID: conversationID, Name: "x"})
+	require.Equal(t, code.NotFound, code.From(err))
+
+	require.NoError(t, s.DeleteList(ctx, conversationID, daily.ID))
+	err = s.DeleteList(ctx, conversationID, daily.ID)
+	require.Equal(t, code.NotFound, code.From(err))
+
+	got, err = s.FindLists(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingList{groceries}, got)
+	gotItems, err = s.Find(ctx, conversationID, daily.ID)
+	require.NoError(t, err)
+	assert.Empty(t, gotItems)
+	gotItems, err = s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingItem{items[0]}, gotItems)
+}
+
+func TestShopping_History(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_History"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+
+	records := []*model.PurchaseRecord{
+		{ID: "record_01", ConversationID: conversationID, Name: "牛乳", Quantity: 1, Event: model.PurchaseEventTypeAdded, At: 1666416720},
+		{ID: "record_02", ConversationID: conversationID, Name: "卵", Quantity: 2, Unit: "パック", Category: "乳製品・卵", Event: model.PurchaseEventTypeAdded, At: 1666416727},
+		{ID: "record_03", ConversationID: conversationID, Name: "牛乳", Quantity: 1, Event: model.PurchaseEventTypeCleared, At: 1666416730},
+	}
+	require.NoError(t, s.AppendHistory(ctx, records[2], records[0]))
+	require.NoError(t, s.AppendHistory(ctx, records[1]))
+	require.NoError(t, s.AppendHistory(ctx))
+	// the history is append-only
+	require.Error(t, s.AppendHistory(ctx, records[0]))
+
+	got, err := s.FindHistory(ctx, conversationID, time.Unix(0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+
+	got, err = s.FindHistory(ctx, conversationID, time.Unix(1666416727, 0))
+	require.NoError(t, err)
+	assert.Equal(t, records[1:], got)
+}
+
+func TestShopping_Snapshot(t *testing.T) {
+	t.Parallel()
+	const conversationID = "TestShopping_Snapshot"
+	ctx := context.Background()
+	conv := NewConversation(testCli)
+	s := NewShopping(conv)
+	items := model.ShoppingItems{
+		{ID: "item_01", Name: "卵", Quantity: 1, Unit: "パック", ConversationID: conversationID, CreatedAt: 1666416720, Order: 0},
+		{ID: "item_02", Name: "牛乳", Quantity: 2, ConversationID: conversationID, CreatedAt: 1666416720, Order: 1},
+	}
+	require.NoError(t, s.Add(ctx, items...))
+
+	old := &model.ShoppingSnapshot{ID: "snapshot_01", ConversationID: conversationID, Items: items[:1], CreatedAt: 1666416720}
+	require.NoError(t, s.SaveSnapshot(ctx, old))
+	snapshot := &model.ShoppingSnapshot{ID: "snapshot_02", ConversationID: conversationID, Items: items, CreatedAt: 1666416727}
+	require.NoError(t, s.SaveSnapshot(ctx, snapshot))
+	require.NoError(t, s.DeleteAll(ctx, conversationID, model.DefaultShoppingListID))
+
+	// the previous snapshot is discarded
+	_, err := s.GetSnapshot(ctx, conversationID, old.ID)
+	require.Equal(t, code.NotFound, code.From(err))
+	got, err := s.GetSnapshot(ctx, conversationID, snapshot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, got)
+
+	require.NoError(t, s.RestoreSnapshot(ctx, conversationID, snapshot.ID))
+	err = s.RestoreSnapshot(ctx, conversationID, snapshot.ID)
+	require.Equal(t, code.NotFound, code.From(err))
+
+	restored, err := s.Find(ctx, conversationID, model.DefaultShoppingListID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ShoppingItem(items), restored)
+}
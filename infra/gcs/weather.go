@@ -56,10 +56,43 @@ func (w *WeatherImageStore) Save(ctx context.Context, r io.Reader, t time.Time)
 	return key, nil
 }
 
-func (w *WeatherImageStore) Get(ctx context.Context, t time.Time, ttl time.Duration) (string, error) {
+// Get returns the latest image created in the day of t in loc.
+// The images are stored by the date in w.loc, so a day in loc spans two dates at most.
+func (w *WeatherImageStore) Get(ctx context.Context, t time.Time, loc *time.Location, ttl time.Duration) (string, error) {
+	year, month, day := t.In(loc).Date()
+	begin := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	dates := []string{t.In(w.loc).Format("20060102")}
+	if date := begin.In(w.loc).Format("20060102"); date != dates[0] {
+		dates = append(dates, date)
+	}
+
+	for _, date := range dates {
+		attrs, err := w.latest(ctx, date)
+		if code.From(err) == code.NotFound {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if attrs.Created.Before(begin) {
+			break
+		}
+		if attrs.Created.Add(ttl).Before(t) {
+			return "", xerrors.Errorf("image is expired")
+		}
+
+		return attrs.Name, nil
+	}
+
+	err := xerrors.Errorf("image is not found")
+	return "", code.With(err, code.NotFound)
+}
+
+// latest returns the latest image of the date, the keys are ordered from the latest.
+func (w *WeatherImageStore) latest(ctx context.Context, date string) (*storage.ObjectAttrs, error) {
 	q := &storage.Query{
 		Delimiter: "/",
-		Prefix:    weatherPrefix + t.In(w.loc).Format("20060102") + "/",
+		Prefix:    weatherPrefix + date + "/",
 	}
 	iter := w.cli.Bucket(w.bucket).Objects(ctx, q)
 	for {
@@ -68,20 +101,17 @@ func (w *WeatherImageStore) Get(ctx context.Context, t time.Time, ttl time.Durat
 			break
 		}
 		if err != nil {
-			return "", xerrors.Errorf("failed to get image: %w", err)
+			return nil, xerrors.Errorf("failed to get image: %w", err)
 		}
 		if !strings.HasSuffix(attrs.Name, objectSuffix) {
 			continue
 		}
-		if attrs.Created.Add(ttl).Before(t) {
-			return "", xerrors.Errorf("image is expired")
-		}
 
-		return attrs.Name, nil
+		return attrs, nil
 	}
 
 	err := xerrors.Errorf("image is not found")
-	return "", code.With(err, code.NotFound)
+	return nil, code.With(err, code.NotFound)
 }
 
 func (w *WeatherImageStore) key(t time.Time) string {
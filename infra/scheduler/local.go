@@ -0,0 +1,343 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/log"
+)
+
+const (
+	localRetryInterval = time.Minute
+	localMaxAttempts   = 3
+)
+
+// Handler executes the schedule and the reminders, it is implemented by usecase.EventHandler.
+type Handler interface {
+	HandleSchedule(context.Context) error
+	HandleReminder(context.Context, *model.ReminderItemIDJSON) error
+}
+
+// Clock abstracts time for the local scheduler.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(time.Duration, func()) Timer
+}
+
+type Timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+type localTask struct {
+	ConversationID string `json:"conversation_id"`
+	ItemID         string `json:"item_id"`
+	ScheduledAt    int64  `json:"scheduled_at"`
+	// RunAt differs from ScheduledAt when the task is retried.
+	RunAt    int64 `json:"run_at"`
+	Attempts int   `json:"attempts,omitempty"`
+}
+
+func (t *localTask) name() string {
+	return taskPrefix + t.ConversationID + "-" + t.ItemID
+}
+
+// Local is an in-process ScheduleSynchronizer which keeps pending tasks in a file.
+// It calls Handler directly instead of Cloud Tasks and Cloud Scheduler.
+type Local struct {
+	clock        Clock
+	file         string
+	syncInterval time.Duration
+
+	mu        sync.Mutex
+	tasks     map[string]*localTask
+	timers    map[string]Timer
+	syncTimer Timer
+	handler   Handler
+	ctx       context.Context //nolint:containedctx
+	stopped   bool
+	wg        sync.WaitGroup
+}
+
+func NewLocal(conf *config.Scheduler, clock Clock) (*Local, error) {
+	l := &Local{
+		clock:        clock,
+		file:         conf.LocalStateFile,
+		syncInterval: conf.LocalSyncInterval,
+		tasks:        make(map[string]*localTask),
+		timers:       make(map[string]Timer),
+	}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Start arms the timers of the pending tasks and the periodic schedule handling.
+// Tasks which have passed while the process was down are executed immediately.
+func (l *Local) Start(ctx context.Context, handler Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.ctx = ctx
+	l.handler = handler
+	for _, task := range l.tasks {
+		l.arm(task)
+	}
+	l.armSync(0)
+}
+
+// Stop stops the timers and waits for running executions.
+func (l *Local) Stop() {
+	l.mu.Lock()
+	l.stopped = true
+	for name, timer := range l.timers {
+		timer.Stop()
+		delete(l.timers, name)
+	}
+	if l.syncTimer != nil {
+		l.syncTimer.Stop()
+	}
+	l.mu.Unlock()
+
+	l.wg.Wait()
+}
+
+func (l *Local) Sync(_ context.Context, conversationID model.ConversationID, items model.ReminderItems, t time.Time) error {
+	tasks := make(map[string]*localTask, len(items))
+	for _, item := range items {
+		task, err := newLocalTask(item, t)
+		if err != nil {
+			return err
+		}
+		tasks[task.name()] = task
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prefix := taskPrefix + conversationID.String() + "-"
+	for name := range l.tasks {
+		if _, ok := tasks[name]; !ok && strings.HasPrefix(name, prefix) {
+			l.remove(name)
+		}
+	}
+	for name, task := range tasks {
+		// keep the task as is while it is retried
+		if old, ok := l.tasks[name]; ok && old.ScheduledAt == task.ScheduledAt {
+			continue
+		}
+		l.put(task)
+	}
+
+	return l.save()
+}
+
+func (l *Local) Create(_ context.Context, _ model.ConversationID, item *model.ReminderItem, t time.Time) error {
+	task, err := newLocalTask(item, t)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.put(task)
+	return l.save()
+}
+
+func (l *Local) Delete(_ context.Context, conversationID model.ConversationID, item *model.ReminderItem, _ time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.remove(taskPrefix + conversationID.String() + "-" + string(item.ID))
+	return l.save()
+}
+
+func newLocalTask(item *model.ReminderItem, t time.Time) (*localTask, error) {
+	next, err := item.Next(t)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get next schedule: %w", err)
+	}
+	return &localTask{
+		ConversationID: item.ConversationID.String(),
+		ItemID:         string(item.ID),
+		ScheduledAt:    next.Unix(),
+		RunAt:          next.Unix(),
+	}, nil
+}
+
+// put adds or replaces the task, l.mu must be held.
+func (l *Local) put(task *localTask) {
+	l.remove(task.name())
+	l.tasks[task.name()] = task
+	l.arm(task)
+}
+
+// remove removes the task, l.mu must be held.
+func (l *Local) remove(name string) {
+	if timer, ok := l.timers[name]; ok {
+		timer.Stop()
+		delete(l.timers, name)
+	}
+	delete(l.tasks, name)
+}
+
+// arm sets the timer of the task if the scheduler is started, l.mu must be held.
+func (l *Local) arm(task *localTask) {
+	if l.handler == nil || l.stopped {
+		return
+	}
+	d := time.Unix(task.RunAt, 0).Sub(l.clock.Now())
+	l.timers[task.name()] = l.clock.AfterFunc(d, func() { l.run(task) })
+}
+
+// armSync sets the timer of the schedule handling, l.mu must be held.
+func (l *Local) armSync(d time.Duration) {
+	if l.stopped {
+		return
+	}
+	l.syncTimer = l.clock.AfterFunc(d, l.runSync)
+}
+
+func (l *Local) run(task *localTask) {
+	l.mu.Lock()
+	// the task has been replaced or removed after the timer fired
+	if l.stopped || l.tasks[task.name()] != task {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.timers, task.name())
+	delete(l.tasks, task.name())
+	if err := l.save(); err != nil {
+		slog.ErrorContext(l.ctx, "scheduler: failed to save tasks", log.Err(err))
+	}
+	ctx, handler := l.ctx, l.handler
+	l.wg.Add(1)
+	l.mu.Unlock()
+	defer l.wg.Done()
+
+	itemIDJSON := &model.ReminderItemIDJSON{
+		ConversationID: task.ConversationID,
+		ItemID:         task.ItemID,
+		ScheduledAt:    task.ScheduledAt,
+	}
+	err := handler.HandleReminder(ctx, itemIDJSON)
+	if err == nil {
+		return
+	}
+
+	slog.ErrorContext(ctx, "scheduler: failed to execute reminder",
+		slog.String("ConversationID", task.ConversationID),
+		slog.String("ItemID", task.ItemID),
+		slog.Int("Attempts", task.Attempts+1),
+		log.Err(err),
+	)
+	if task.Attempts+1 >= localMaxAttempts {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// do not overwrite the task created while the handler was running
+	if _, ok := l.tasks[task.name()]; ok {
+		return
+	}
+	retry := *task
+	retry.Attempts++
+	retry.RunAt = l.clock.Now().Add(localRetryInterval).Unix()
+	l.put(&retry)
+	if err := l.save(); err != nil {
+		slog.ErrorContext(ctx, "scheduler: failed to save tasks", log.Err(err))
+	}
+}
+
+func (l *Local) runSync() {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return
+	}
+	ctx, handler := l.ctx, l.handler
+	l.wg.Add(1)
+	l.mu.Unlock()
+	defer l.wg.Done()
+
+	if err := handler.HandleSchedule(ctx); err != nil {
+		slog.ErrorContext(ctx, "scheduler: failed to handle schedule", log.Err(err))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.armSync(l.syncInterval)
+}
+
+func (l *Local) load() error {
+	data, err := os.ReadFile(l.file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return xerrors.Errorf("failed to read tasks: %w", err)
+	}
+
+	var tasks []*localTask
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return xerrors.Errorf("failed to unmarshal tasks: %w", err)
+	}
+	for _, task := range tasks {
+		l.tasks[task.name()] = task
+	}
+
+	return nil
+}
+
+// save writes the tasks to the file atomically, l.mu must be held.
+func (l *Local) save() error {
+	tasks := make([]*localTask, 0, len(l.tasks))
+	for _, task := range l.tasks {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].name() < tasks[j].name()
+	})
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal tasks: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.file), filepath.Base(l.file)+".*")
+	if err != nil {
+		return xerrors.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return xerrors.Errorf("failed to write tasks: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return xerrors.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.file); err != nil {
+		return xerrors.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	f     func()
+	done  bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward and fires the due timers in order.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	for {
+		due := make([]*fakeTimer, 0, len(c.timers))
+		for _, t := range c.timers {
+			if !t.done && !t.at.After(c.now) {
+				due = append(due, t)
+			}
+		}
+		if len(due) == 0 {
+			break
+		}
+		sort.SliceStable(due, func(i, j int) bool { return due[i].at.Before(due[j].at) })
+		due[0].done = true
+		c.mu.Unlock()
+		due[0].f()
+		c.mu.Lock()
+	}
+	c.mu.Unlock()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := !t.done
+	t.done = true
+	return active
+}
+
+type fakeHandler struct {
+	mu        sync.Mutex
+	reminders []*model.ReminderItemIDJSON
+	schedules int
+	err       error
+}
+
+func (h *fakeHandler) HandleSchedule(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.schedules++
+	return nil
+}
+
+func (h *fakeHandler) HandleReminder(_ context.Context, itemIDJSON *model.ReminderItemIDJSON) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reminders = append(h.reminders, itemIDJSON)
+	return h.err
+}
+
+func newTestLocal(t *testing.T, file string, clock Clock) *Local {
+	t.Helper()
+	l, err := NewLocal(&config.Scheduler{
+		Backend:           config.SchedulerBackendLocal,
+		LocalStateFile:    file,
+		LocalSyncInterval: time.Hour,
+	}, clock)
+	require.NoError(t, err)
+	t.Cleanup(l.Stop)
+	return l
+}
+
+func oneshotItem(conversationID model.ConversationID, id model.ReminderItemID, t time.Time) *model.ReminderItem {
+	return &model.ReminderItem{
+		ConversationID: conversationID,
+		ID:             id,
+		Scheduler:      &model.OneshotScheduler{Time: t},
+		Executor:       &model.Executor{Type: model.ExecutorTypeShoppingList},
+	}
+}
+
+func TestLocal_Create(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+	handler := &fakeHandler{}
+	l := newTestLocal(t, filepath.Join(t.TempDir(), "scheduler.json"), clock)
+	l.Start(ctx, handler)
+
+	item := oneshotItem("LU-test", "item1", now.Add(10*time.Minute))
+	require.NoError(t, l.Create(ctx, item.ConversationID, item, now))
+
+	clock.Advance(9 * time.Minute)
+	assert.Empty(t, handler.reminders)
+	clock.Advance(time.Minute)
+	assert.Equal(t, []*model.ReminderItemIDJSON{
+		{ConversationID: "LU-test", ItemID: "item1", ScheduledAt: now.Add(10 * time.Minute).Unix()},
+	}, handler.reminders)
+	assert.Empty(t, l.tasks)
+}
+
+func TestLocal_Restart(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "scheduler.json")
+	now := time.Unix(1700000000, 0)
+	item := oneshotItem("LU-test", "item1", now.Add(10*time.Minute))
+
+	l := newTestLocal(t, file, &fakeClock{now: now})
+	require.NoError(t, l.Create(ctx, item.ConversationID, item, now))
+	l.Stop()
+
+	// the task has passed while the process was down
+	clock := &fakeClock{now: now.Add(time.Hour)}
+	handler := &fakeHandler{}
+	restarted := newTestLocal(t, file, clock)
+	restarted.Start(ctx, handler)
+	clock.Advance(0)
+	require.Len(t, handler.reminders, 1)
+	assert.Equal(t, "item1", handler.reminders[0].ItemID)
+	assert.Equal(t, 1, handler.schedules)
+}
+
+func TestLocal_Sync(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+	handler := &fakeHandler{}
+	l := newTestLocal(t, filepath.Join(t.TempDir(), "scheduler.json"), clock)
+	l.Start(ctx, handler)
+
+	stale := oneshotItem("LU-test", "stale", now.Add(10*time.Minute))
+	other := oneshotItem("LU-other", "other", now.Add(10*time.Minute))
+	require.NoError(t, l.Create(ctx, stale.ConversationID, stale, now))
+	require.NoError(t, l.Create(ctx, other.ConversationID, other, now))
+
+	item := oneshotItem("LU-test", "item1", now.Add(20*time.Minute))
+	require.NoError(t, l.Sync(ctx, "LU-test", model.ReminderItems{item}, now))
+
+	clock.Advance(30 * time.Minute)
+	got := make([]string, 0, len(handler.reminders))
+	for _, r := range handler.reminders {
+		got = append(got, r.ItemID)
+	}
+	assert.Equal(t, []string{"other", "item1"}, got)
+}
+
+func TestLocal_Delete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+	handler := &fakeHandler{}
+	l := newTestLocal(t, filepath.Join(t.TempDir(), "scheduler.json"), clock)
+	l.Start(ctx, handler)
+
+	item := oneshotItem("LU-test", "item1", now.Add(10*time.Minute))
+	require.NoError(t, l.Create(ctx, item.ConversationID, item, now))
+	require.NoError(t, l.Delete(ctx, item.ConversationID, item, now))
+	// deleting a missing task is not an error
+	require.NoError(t, l.Delete(ctx, item.ConversationID, item, now))
+
+	clock.Advance(time.Hour)
+	assert.Empty(t, handler.reminders)
+}
+
+func TestLocal_Retry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+	handler := &fakeHandler{err: errors.New("push failed")}
+	l := newTestLocal(t, filepath.Join(t.TempDir(), "scheduler.json"), clock)
+	l.Start(ctx, handler)
+
+	item := oneshotItem("LU-test", "item1", now.Add(10*time.Minute))
+	require.NoError(t, l.Create(ctx, item.ConversationID, item, now))
+
+	clock.Advance(10 * time.Minute)
+	require.Len(t, handler.reminders, 1)
+	clock.Advance(localRetryInterval)
+	require.Len(t, handler.reminders, 2)
+	clock.Advance(time.Hour)
+	require.Len(t, handler.reminders, localMaxAttempts)
+	// retries keep the scheduled time for deduplication
+	for _, r := range handler.reminders {
+		assert.Equal(t, now.Add(10*time.Minute).Unix(), r.ScheduledAt)
+	}
+}
+
+func TestLocal_HandleSchedule(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+	handler := &fakeHandler{}
+	l := newTestLocal(t, filepath.Join(t.TempDir(), "scheduler.json"), clock)
+	l.Start(context.Background(), handler)
+
+	clock.Advance(0)
+	assert.Equal(t, 1, handler.schedules)
+	clock.Advance(59 * time.Minute)
+	assert.Equal(t, 1, handler.schedules)
+	clock.Advance(time.Minute)
+	assert.Equal(t, 2, handler.schedules)
+}
@@ -22,10 +22,32 @@ import (
 
 // Set provides a wire set.
 var Set = wire.NewSet(
-	New,
-	wire.Bind(new(repository.ScheduleSynchronizer), new(*Scheduler)),
+	NewSynchronizer,
 )
 
+// NewSynchronizer returns the ScheduleSynchronizer of the configured backend.
+func NewSynchronizer(
+	ctx context.Context,
+	conf *config.Scheduler,
+	lc *config.LINEBot,
+	cs *config.ServiceEndpoint,
+) (repository.ScheduleSynchronizer, func(), error) {
+	switch conf.Backend {
+	case config.SchedulerBackendLocal:
+		l, err := NewLocal(conf, realClock{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return l, l.Stop, nil
+	default:
+		s, err := New(ctx, lc, cs)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s, func() {}, nil
+	}
+}
+
 const (
 	taskPrefix       = "reminder-"
 	reminderEndpoint = "/reminder"
@@ -42,6 +64,10 @@ type Scheduler struct {
 }
 
 func New(ctx context.Context, conf *config.LINEBot, cs *config.ServiceEndpoint) (*Scheduler, error) {
+	if conf.CloudTasksLocation == "" || conf.CloudTasksQueue == "" {
+		return nil, xerrors.New("CLOUD_TASKS_LOCATION and CLOUD_TASKS_QUEUE are required")
+	}
+
 	projectID, err := gcp.ProjectID()
 	if err != nil {
 		return nil, xerrors.Errorf("gcp.ProjectID: %w", err)
@@ -119,11 +145,13 @@ func (s *Scheduler) Delete(ctx context.Context, conversationID model.Conversatio
 }
 
 func (s *Scheduler) reminderItemToTask(prefix string, item *model.ReminderItem, t time.Time) (*scheduler.Task, error) {
-	next, err := item.Scheduler.Next(t)
+	next, err := item.Next(t)
 	if err != nil {
-		return nil, xerrors.New("failed to get next schedule")
+		return nil, xerrors.Errorf("failed to get next schedule: %w", err)
 	}
-	data, err := json.Marshal(item.IDJSON())
+	idJSON := item.IDJSON()
+	idJSON.ScheduledAt = next.Unix()
+	data, err := json.Marshal(idJSON)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to marshal json: %w", err)
 	}
@@ -142,6 +170,7 @@ func (s *Scheduler) reminderItemToTask(prefix string, item *model.ReminderItem,
 			ServiceAccountEmail: s.invokerServiceAccountEmail,
 			Audience:            s.audience,
 		},
-		Version: 1,
+		// the version is 1 or more
+		Version: item.Revision + 1,
 	}, nil
 }
@@ -0,0 +1,77 @@
+package interactor
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/domain/service"
+)
+
+const (
+	triggerCancel        = "キャンセル"
+	triggerCancelEnglish = "cancel"
+	cancelPostback       = "Conversation#cancel"
+)
+
+// Cancel leaves the flow in progress such as adding shopping items by "キャンセル" or the quick reply.
+type Cancel struct {
+	conversation service.Conversation
+	message      repository.MessageProviderSet
+	bot          service.Bot
+}
+
+func NewCancel(
+	conversation service.Conversation,
+	message repository.MessageProviderSet,
+	bot service.Bot,
+) *Cancel {
+	return &Cancel{
+		conversation: conversation,
+		message:      message,
+		bot:          bot,
+	}
+}
+
+func (c *Cancel) Handle(ctx context.Context, e *model.Event) error {
+	err := e.HandleTypeMessage(ctx, func(context.Context, *model.Event) error {
+		lines := e.ReadTextLines()
+		if len(lines) != 1 || (lines[0] != triggerCancel && !strings.EqualFold(lines[0], triggerCancelEnglish)) {
+			return nil
+		}
+		return c.cancel(ctx, e)
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to handle type message: %w", err)
+	}
+
+	err = e.HandleTypePostback(ctx, func(context.Context, *model.Event) error {
+		if e.Postback.Data != cancelPostback {
+			return nil
+		}
+		return c.cancel(ctx, e)
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to handle type postback: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cancel) cancel(ctx context.Context, e *model.Event) error {
+	status := &model.ConversationStatus{
+		ConversationID: e.ConversationID(),
+		Type:           model.ConversationStatusTypeNeutral,
+	}
+	if err := c.conversation.SetStatus(ctx, status); err != nil {
+		return xerrors.Errorf("failed to set status: %w", err)
+	}
+	msg := c.message.Localize(e.Language).Text(printer(e.Language).Sprintf("キャンセルしました。"))
+	if err := c.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
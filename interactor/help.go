@@ -0,0 +1,66 @@
+package interactor
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/domain/service"
+)
+
+const helpText = `【ヘルプ】
+次のように話しかけて下さい。
+
+■買い物リスト
+・買い物リスト
+・牛乳と卵を買い物リストに追加
+・1番を削除、牛乳を削除
+・2番買った
+・2番をバター無塩に変更
+・いつもの
+
+■リマインダー
+・リマインダー
+・明日の8時に買い物リストをリマインド
+
+■天気
+・今日の天気は？
+
+■設定
+・タイムゾーン Asia/Tokyo
+・単語登録 オイコス おいこす
+・言語 English`
+
+type Help struct {
+	message repository.MessageProviderSet
+	bot     service.Bot
+}
+
+func NewHelp(
+	message repository.MessageProviderSet,
+	bot service.Bot,
+) *Help {
+	return &Help{
+		message: message,
+		bot:     bot,
+	}
+}
+
+func (h *Help) Handle(ctx context.Context, e *model.Event) error {
+	err := e.HandleTypeMessage(ctx, func(context.Context, *model.Event) error {
+		if e.IntentType() != model.IntentTypeHelp {
+			return nil
+		}
+		if err := h.bot.ReplyMessage(ctx, e, h.message.Localize(e.Language).Text(printer(e.Language).Sprintf(helpText))); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to handle type message: %w", err)
+	}
+
+	return nil
+}
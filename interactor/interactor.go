@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/wire"
+	"golang.org/x/text/message"
 	"golang.org/x/xerrors"
 
 	"github.com/ww24/linebot/domain/model"
@@ -13,6 +16,7 @@ import (
 	"github.com/ww24/linebot/domain/service"
 	"github.com/ww24/linebot/internal/code"
 	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/internal/i18n"
 	"github.com/ww24/linebot/log"
 	"github.com/ww24/linebot/usecase"
 )
@@ -25,11 +29,14 @@ var (
 var Set = wire.NewSet(
 	NewEventHandler,
 	wire.Bind(new(usecase.EventHandler), new(*EventHandler)),
+	NewCancel,
 	NewReminder,
+	NewSetting,
 	NewShopping,
 	NewScreenshot,
 	wire.Bind(new(usecase.ScreenshotHandler), new(*Screenshot)),
 	NewWeather,
+	NewHelp,
 	NewImage,
 	wire.Bind(new(usecase.ImageHandler), new(*Image)),
 )
@@ -40,36 +47,53 @@ type EventHandler struct {
 	remindHandlers   []repository.RemindHandler
 	conversation     service.Conversation
 	reminder         service.Reminder
+	dictionary       service.Dictionary
 	conversationIDs  *config.ConversationIDs
-	bot              service.Bot
-	message          repository.MessageProviderSet
+	// loc is the time zone of the conversations without the setting.
+	loc     *time.Location
+	bot     service.Bot
+	message repository.MessageProviderSet
 }
 
 func NewEventHandler(
+	cancelInteractor *Cancel,
 	shoppingInteractor *Shopping,
 	reminderInteractor *Reminder,
 	weatherInteractor *Weather,
+	settingInteractor *Setting,
+	helpInteractor *Help,
 	conversation service.Conversation,
 	reminder service.Reminder,
+	dictionary service.Dictionary,
 	message repository.MessageProviderSet,
 	bot service.Bot,
 	conf *config.LINEBot,
+	ct *config.Time,
 ) (*EventHandler, error) {
 	return &EventHandler{
 		handlers: []repository.Handler{
-			shoppingInteractor,
+			// cancel goes first to leave any flow in progress
+			cancelInteractor,
+			settingInteractor,
+			// reminder goes before shopping to catch texts like "明日の8時に買い物リストをリマインド"
 			reminderInteractor,
+			shoppingInteractor,
 			weatherInteractor,
+			helpInteractor,
 		},
 		scheduleHandlers: []repository.ScheduleHandler{
 			reminderInteractor,
 		},
 		remindHandlers: []repository.RemindHandler{
 			shoppingInteractor,
+			reminderInteractor,
+			weatherInteractor,
 		},
 		conversation:    conversation,
 		reminder:        reminder,
+		dictionary:      dictionary,
 		conversationIDs: conf.ConversationIDs(),
+		loc:             ct.DefaultLocation(),
 		bot:             bot,
 		message:         message,
 	}, nil
@@ -88,7 +112,20 @@ func (h *EventHandler) Handle(ctx context.Context, events []*model.Event) error
 		if err != nil {
 			return xerrors.Errorf("failed to get status: %w", err)
 		}
-		e.SetStatus(status.Type)
+		e.Status = status
+
+		setting, err := h.conversation.Setting(ctx, e.ConversationID())
+		if err != nil {
+			return xerrors.Errorf("failed to get setting: %w", err)
+		}
+		e.Location = setting.Location(h.loc)
+		e.Language = setting.Language.OrDefault()
+
+		intent, err := h.dictionary.Classify(ctx, e.ConversationID(), e.Language, strings.Join(e.ReadTextLines(), " "))
+		if err != nil {
+			return xerrors.Errorf("failed to classify: %w", err)
+		}
+		e.Intent = intent
 
 		for _, handler := range h.handlers {
 			if err := handler.Handle(ctx, e); err != nil {
@@ -106,7 +143,7 @@ func (h *EventHandler) Handle(ctx context.Context, events []*model.Event) error
 }
 
 func (h *EventHandler) handleError(ctx context.Context, e *model.Event) error {
-	msg := h.message.Text("予期せぬエラーが発生しました")
+	msg := h.message.Text(printer(e.Language).Sprintf("予期せぬエラーが発生しました"))
 	if err := h.bot.PushMessage(ctx, e.ConversationID(), msg); err != nil {
 		return xerrors.Errorf("bot.PushMessage: %w", err)
 	}
@@ -114,6 +151,19 @@ func (h *EventHandler) handleError(ctx context.Context, e *model.Event) error {
 	return nil
 }
 
+// printer returns the printer of the message catalogue in the language.
+func printer(lang model.Language) *message.Printer {
+	return i18n.Printer(lang.Tag())
+}
+
+// translator translates the labels of the lists printed by the models such as "購入済み".
+// The labels include the category names of the configuration, "%" of them is not a verb.
+func translator(p *message.Printer) model.Translator {
+	return func(text string) string {
+		return p.Sprintf(strings.ReplaceAll(text, "%", "%%"))
+	}
+}
+
 func (h *EventHandler) HandleSchedule(ctx context.Context) error {
 	for _, handler := range h.scheduleHandlers {
 		if err := handler.HandleSchedule(ctx); err != nil {
@@ -137,7 +187,42 @@ func (h *EventHandler) HandleReminder(ctx context.Context, itemIDJSON *model.Rem
 		}
 		return xerrors.Errorf("failed to get reminder item: %w", err)
 	}
+	if item.Paused {
+		slog.InfoContext(ctx, "interactor: reminder item is paused",
+			slog.String("ConversationID", itemIDJSON.ConversationID),
+			slog.String("ItemID", itemIDJSON.ItemID),
+		)
+		return nil
+	}
+
+	// old tasks have no scheduled time and can not be deduplicated
+	if itemIDJSON.ScheduledAt == 0 {
+		return h.handleReminder(ctx, item)
+	}
+
+	delivery := model.NewDelivery(item, time.Unix(itemIDJSON.ScheduledAt, 0), time.Now())
+	if err := h.reminder.BeginDelivery(ctx, delivery); err != nil {
+		if code.From(err) == code.AlreadyExists {
+			slog.InfoContext(ctx, "interactor: reminder is already delivered",
+				slog.String("ConversationID", itemIDJSON.ConversationID),
+				slog.String("ItemID", itemIDJSON.ItemID),
+				log.Err(err),
+			)
+			return nil
+		}
+		return xerrors.Errorf("failed to begin delivery: %w", err)
+	}
+
+	herr := h.handleReminder(ctx, item)
+	delivery.Finish(time.Now(), herr)
+	if err := h.reminder.FinishDelivery(ctx, delivery); err != nil {
+		return xerrors.Errorf("failed to finish delivery: %w", err)
+	}
+
+	return herr
+}
 
+func (h *EventHandler) handleReminder(ctx context.Context, item *model.ReminderItem) error {
 	for _, handler := range h.remindHandlers {
 		if err := handler.HandleReminder(ctx, item); err != nil {
 			return xerrors.Errorf("failed to handle reminder: %w", err)
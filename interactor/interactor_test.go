@@ -0,0 +1,31 @@
+package interactor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+func TestTranslator(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		lang model.Language
+		text string
+		want string
+	}{
+		{lang: model.LanguageEnglish, text: "購入済み", want: "Bought"},
+		{lang: model.LanguageJapanese, text: "購入済み", want: "購入済み"},
+		// a category name of the configuration
+		{lang: model.LanguageEnglish, text: "果汁100%", want: "果汁100%"},
+		{lang: model.LanguageEnglish, text: "%s%d", want: "%s%d"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, translator(printer(tt.lang))(tt.text))
+		})
+	}
+}
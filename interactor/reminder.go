@@ -2,60 +2,82 @@ package interactor
 
 import (
 	"context"
-	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/text/message"
 	"golang.org/x/xerrors"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
 	"github.com/ww24/linebot/domain/service"
-	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/internal/code"
 )
 
 const (
-	triggerReminder = "リマインダー"
-	prefixReminder  = "【リマインダー】"
+	prefixReminder = "【リマインダー】"
 
+	reminderAddPrefix           = "Reminder#add#"
 	reminderDeletePrefix        = "Reminder#delete#"
 	reminderDeleteConfirmPrefix = "Reminder#delete#confirm#"
+	reminderSnoozePrefix        = "Reminder#snooze#"
+	reminderAckPrefix           = "Reminder#ack#"
+	reminderEditPrefix          = "Reminder#edit#"
+	reminderEditDatetimePrefix  = "Reminder#edit#datetime#"
+	reminderEditTimePrefix      = "Reminder#edit#time#"
+	reminderPausePrefix         = "Reminder#pause#"
+	reminderResumePrefix        = "Reminder#resume#"
+
+	datetimePickerLayout = "2006-01-02T15:04"
 )
 
 type Reminder struct {
 	conversation   service.Conversation
 	reminder       service.Reminder
+	shopping       service.Shopping
+	scheduleParser repository.ScheduleParser
 	message        repository.MessageProviderSet
 	bot            service.Bot
-	loc            *time.Location
-	timeZoneOffset time.Duration
 }
 
 func NewReminder(
 	conversation service.Conversation,
 	reminder service.Reminder,
+	shopping service.Shopping,
+	scheduleParser repository.ScheduleParser,
 	message repository.MessageProviderSet,
 	bot service.Bot,
-	conf *config.Time,
 ) *Reminder {
-	loc := conf.DefaultLocation()
-	_, offset := time.Time{}.In(loc).Zone()
-
 	return &Reminder{
 		conversation:   conversation,
 		reminder:       reminder,
+		shopping:       shopping,
+		scheduleParser: scheduleParser,
 		message:        message,
 		bot:            bot,
-		loc:            conf.DefaultLocation(),
-		timeZoneOffset: time.Duration(offset) * time.Second,
 	}
 }
 
 func (r *Reminder) Handle(ctx context.Context, e *model.Event) error {
 	err := e.HandleTypeMessage(ctx, func(context.Context, *model.Event) error {
-		if e.FilterText(triggerReminder) {
+		if e.Status.Type == model.ConversationStatusTypeReminderAddMessage {
+			return r.handleStatus(ctx, e)
+		}
+		// a text such as "リマインダー付きタイマー" is an input of the flow in progress
+		if e.Status.InputPending() {
+			return nil
+		}
+		if e.IntentType() == model.IntentTypeShowReminders {
 			return r.handleMenu(ctx, e)
 		}
+		if e.IntentType() == model.IntentTypeSetReminder {
+			// the schedule parser reads Japanese texts only
+			if e.Language == model.LanguageEnglish {
+				return r.startAdd(ctx, e)
+			}
+			return r.handleText(ctx, e)
+		}
 
 		return nil
 	})
@@ -76,18 +98,19 @@ func (r *Reminder) handleMenu(ctx context.Context, e *model.Event) error {
 		return xerrors.Errorf("failed to list reminder items: %w", err)
 	}
 
+	p := printer(e.Language)
 	if len(items) == 0 {
-		text := prefixReminder + "登録されていません。\n何をしますか？"
-		msg := r.message.ReminderMenu(text, model.ReminderReplyTypeEmptyList, nil)
+		text := p.Sprintf(prefixReminder + "登録されていません。\n何をしますか？")
+		msg := r.message.Localize(e.Language).ReminderMenu(text, model.ReminderReplyTypeEmptyList, nil, e.Location)
 		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
 		return errResponseReturned
 	}
 
-	test := fmt.Sprintf(prefixReminder+"%d件登録されています。\n%s\n\n何をしますか？",
-		len(items), items.Print(model.ListTypeOrdered))
-	msg := r.message.ReminderMenu(test, model.ReminderReplyTypeAll, items)
+	test := p.Sprintf(prefixReminder+"%d件登録されています。\n%s\n\n何をしますか？",
+		len(items), items.Print(model.ListTypeOrdered, translator(p)))
+	msg := r.message.Localize(e.Language).ReminderMenu(test, model.ReminderReplyTypeAll, items, e.Location)
 	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 		return xerrors.Errorf("failed to reply message: %w", err)
 	}
@@ -96,70 +119,256 @@ func (r *Reminder) handleMenu(ctx context.Context, e *model.Event) error {
 }
 
 func (r *Reminder) handlePostBack(ctx context.Context, e *model.Event) error {
-	conversationID := e.ConversationID()
-
 	switch e.Postback.Data {
 	case "Reminder#add":
-		status := &model.ConversationStatus{
-			ConversationID: conversationID,
-			Type:           model.ConversationStatusTypeReminderAdd,
+		return r.startAdd(ctx, e)
+	case "Reminder#cancel":
+		return r.handleMenu(ctx, e)
+	}
+
+	switch {
+	case strings.HasPrefix(e.Postback.Data, reminderAddPrefix):
+		return r.handleAdd(ctx, e)
+	case strings.HasPrefix(e.Postback.Data, reminderSnoozePrefix):
+		return r.handleSnooze(ctx, e)
+	case strings.HasPrefix(e.Postback.Data, reminderAckPrefix):
+		return r.handleAck(ctx, e)
+	case strings.HasPrefix(e.Postback.Data, reminderEditPrefix):
+		return r.handleEdit(ctx, e)
+	case strings.HasPrefix(e.Postback.Data, reminderPausePrefix):
+		return r.handlePause(ctx, e, strings.TrimPrefix(e.Postback.Data, reminderPausePrefix), true)
+	case strings.HasPrefix(e.Postback.Data, reminderResumePrefix):
+		return r.handlePause(ctx, e, strings.TrimPrefix(e.Postback.Data, reminderResumePrefix), false)
+	}
+
+	if err := r.handleDelete(ctx, e); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// startAdd asks what to remind to add a new reminder item.
+func (r *Reminder) startAdd(ctx context.Context, e *model.Event) error {
+	status := &model.ConversationStatus{
+		ConversationID: e.ConversationID(),
+		Type:           model.ConversationStatusTypeReminderAdd,
+	}
+	if err := r.conversation.SetStatus(ctx, status); err != nil {
+		return xerrors.Errorf("failed to set status: %w", err)
+	}
+	p := printer(e.Language)
+	types := []model.ExecutorType{model.ExecutorTypeShoppingList, model.ExecutorTypeMessage, model.ExecutorTypeWeather}
+	labels := make([]string, 0, len(types))
+	for _, typ := range types {
+		labels = append(labels, executorText(p, &model.Executor{Type: typ}))
+	}
+	text := p.Sprintf(prefixReminder + "新規追加します。\n何をリマインドしますか？")
+	msg := r.message.Localize(e.Language).ReminderChoices(text, labels, types)
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleAdd handles postbacks of "Reminder#add#{executor type}[#{step}]".
+func (r *Reminder) handleAdd(ctx context.Context, e *model.Event) error {
+	data := strings.TrimPrefix(e.Postback.Data, reminderAddPrefix)
+	typ, step, _ := strings.Cut(data, "#")
+	executorType, err := model.ParseExecutorType(typ)
+	if err != nil {
+		return xerrors.Errorf("failed to parse executor type: %w", err)
+	}
+	prefix := reminderAddPrefix + executorType.String()
+	p := printer(e.Language)
+
+	switch step {
+	case "":
+		if executorType == model.ExecutorTypeMessage {
+			status := &model.ConversationStatus{
+				ConversationID: e.ConversationID(),
+				Type:           model.ConversationStatusTypeReminderAddMessage,
+			}
+			if err := r.conversation.SetStatus(ctx, status); err != nil {
+				return xerrors.Errorf("failed to set status: %w", err)
+			}
+			msg := r.message.Localize(e.Language).Prompt(p.Sprintf(prefixReminder + "リマインドするメッセージを入力してください。"))
+			if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
 		}
-		if err := r.conversation.SetStatus(ctx, status); err != nil {
-			return xerrors.Errorf("failed to set status: %w", err)
+
+		text := p.Sprintf(prefixReminder+"%sをリマインドします。\n1回だけリマインドしますか？毎日リマインドしますか？", executorText(p, &model.Executor{Type: executorType}))
+		msg := r.message.Localize(e.Language).ReminderScheduleChoices(text, executorType)
+		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply message: %w", err)
 		}
-		text := prefixReminder + "新規追加します。\n何をリマインドしますか？"
-		msg := r.message.ReminderChoices(text,
-			[]string{"買い物リスト"}, []model.ExecutorType{model.ExecutorTypeShoppingList})
+		return errResponseReturned
+
+	case "once":
+		text := p.Sprintf(prefixReminder + "いつリマインドしますか？")
+		msg := r.message.Localize(e.Language).DateTimePicker(text, prefix+"#once#datetime", time.Now().In(e.Location))
 		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
 		return errResponseReturned
 
-	case "Reminder#add#shopping_list":
-		text := prefixReminder + "買い物リストをリマインドします。\n何時にリマインドしますか？"
-		msg := r.message.TimePicker(text, "Reminder#add#shopping_list#datetime")
+	case "once#datetime":
+		t, err := time.ParseInLocation(datetimePickerLayout, e.Postback.Params.Datetime, e.Location)
+		if err != nil {
+			return xerrors.Errorf("failed to parse datetime: %w", err)
+		}
+		now := time.Now()
+		if !t.After(now) {
+			text := p.Sprintf(prefixReminder + "過去の日時は指定できません。\n未来の日時を選択してください。")
+			msg := r.message.Localize(e.Language).DateTimePicker(text, e.Postback.Data, now.In(e.Location))
+			if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply message: %w", err)
+			}
+			return errResponseReturned
+		}
+		return r.addItem(ctx, e, &model.OneshotScheduler{Time: t}, executorType)
+
+	case "repeat":
+		text := p.Sprintf(prefixReminder + "毎日何時にリマインドしますか？")
+		msg := r.message.Localize(e.Language).TimePicker(text, prefix+"#datetime")
 		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
 		return errResponseReturned
 
-	case "Reminder#add#shopping_list#datetime":
+	case "datetime":
 		t, err := time.Parse("15:04", e.Postback.Params.Time)
 		if err != nil {
 			return xerrors.Errorf("failed to parse time: %w", err)
 		}
-		t = t.In(r.loc).Add(-r.timeZoneOffset)
-		text := prefixReminder + "毎日" + t.Format("15:04") + "に買い物リストをリマインドします。"
-		if err := r.bot.ReplyMessage(ctx, e, r.message.Text(text)); err != nil {
+		year, month, day := time.Now().In(e.Location).Date()
+		t = time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, e.Location)
+		return r.addItem(ctx, e, &model.DailyScheduler{Time: t}, executorType)
+	}
+
+	return nil
+}
+
+func (r *Reminder) addItem(ctx context.Context, e *model.Event, scheduler model.Scheduler, executorType model.ExecutorType) error {
+	conversationID := e.ConversationID()
+	p := printer(e.Language)
+
+	executor := &model.Executor{
+		Type: executorType,
+	}
+	if executorType == model.ExecutorTypeMessage {
+		if e.Status.Type != model.ConversationStatusTypeReminderAdd || e.Status.Payload == "" {
+			msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder + "メッセージが見つかりませんでした。\nもう一度最初からやり直してください。"))
+			if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
+		}
+		executor.Payload = e.Status.Payload
+	}
+	target := executorText(p, executor)
+	if executorType == model.ExecutorTypeShoppingList {
+		t, err := r.setShoppingList(ctx, p, conversationID, executor, "")
+		if err != nil {
+			return err
+		}
+		target = t
+	}
+
+	text := p.Sprintf(prefixReminder+"%sに%sをリマインドします。", scheduleText(p, scheduler), target)
+	if err := r.bot.ReplyMessage(ctx, e, r.message.Localize(e.Language).Text(text)); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	item := model.NewReminderItem(conversationID, scheduler, executor)
+	if err := r.reminder.Add(ctx, item); err != nil {
+		return xerrors.Errorf("failed to add reminder item: %w", err)
+	}
+	status := &model.ConversationStatus{
+		ConversationID: conversationID,
+		Type:           model.ConversationStatusTypeNeutral,
+	}
+	if err := r.conversation.SetStatus(ctx, status); err != nil {
+		return xerrors.Errorf("failed to set status: %w", err)
+	}
+	return errResponseReturned
+}
+
+func (r *Reminder) handleStatus(ctx context.Context, e *model.Event) error {
+	text := strings.Join(e.ReadTextLines(), "\n")
+	if text == "" {
+		return nil
+	}
+	status := &model.ConversationStatus{
+		ConversationID: e.ConversationID(),
+		Type:           model.ConversationStatusTypeReminderAdd,
+		Payload:        text,
+	}
+	if err := r.conversation.SetStatus(ctx, status); err != nil {
+		return xerrors.Errorf("failed to set status: %w", err)
+	}
+
+	text = printer(e.Language).Sprintf(prefixReminder+"「%s」をリマインドします。\n1回だけリマインドしますか？毎日リマインドしますか？", text)
+	msg := r.message.Localize(e.Language).ReminderScheduleChoices(text, model.ExecutorTypeMessage)
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleText creates a reminder item from a single message such as "明日の8時に買い物リストをリマインド".
+func (r *Reminder) handleText(ctx context.Context, e *model.Event) error {
+	now := time.Now().In(e.Location)
+	p := printer(e.Language)
+	text := strings.Join(e.ReadTextLines(), " ")
+	exclusion, text := r.scheduleParser.ParseExclusion(text, now)
+	scheduler, subject, ok := r.scheduleParser.ParseSchedule(text, now)
+	if !ok {
+		msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder + "日時が見つかりませんでした。\n「明日の8時に買い物リストをリマインド」のように入力してみて下さい。"))
+		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply text message: %w", err)
 		}
-		item := model.NewReminderItem(
-			conversationID,
-			&model.DailyScheduler{
-				Time: t,
-			},
-			&model.Executor{
-				Type: model.ExecutorTypeShoppingList,
-			},
-		)
-		if err := r.reminder.Add(ctx, item); err != nil {
-			return xerrors.Errorf("failed to add reminder item: %w", err)
-		}
-		status := &model.ConversationStatus{
-			ConversationID: conversationID,
-			Type:           model.ConversationStatusTypeNeutral,
-		}
-		if err := r.conversation.SetStatus(ctx, status); err != nil {
-			return xerrors.Errorf("failed to set status: %w", err)
+		return errResponseReturned
+	}
+
+	executor := executorFromSubject(subject)
+	if executor == nil {
+		msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder + "何をリマインドするか見つかりませんでした。\n「明日の8時に買い物リストをリマインド」のように入力してみて下さい。"))
+		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
 		}
 		return errResponseReturned
 	}
 
-	if err := r.handleDelete(ctx, e); err != nil {
-		return err
+	target := executorText(p, executor)
+	if executor.Type == model.ExecutorTypeShoppingList {
+		t, err := r.setShoppingList(ctx, p, e.ConversationID(), executor, subject)
+		if err != nil {
+			return err
+		}
+		target = t
 	}
 
-	return nil
+	item := model.NewReminderItem(e.ConversationID(), scheduler, executor)
+	item.Exclusion = exclusion
+	if item.Ended(now) {
+		msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder + "過去の日時は指定できません。"))
+		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+	if err := r.reminder.Add(ctx, item); err != nil {
+		return xerrors.Errorf("failed to add reminder item: %w", err)
+	}
+
+	text = p.Sprintf(prefixReminder+"%sに%sをリマインドします。", scheduleText(p, scheduler)+exclusionText(p, exclusion), target)
+	msg := r.message.Localize(e.Language).ReminderAdded(text, reminderDeleteConfirmPrefix+string(item.ID))
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
 }
 
 func (r *Reminder) handleDelete(ctx context.Context, e *model.Event) error {
@@ -169,7 +378,7 @@ func (r *Reminder) handleDelete(ctx context.Context, e *model.Event) error {
 		if err := r.reminder.Delete(ctx, e.ConversationID(), model.ReminderItemID(id)); err != nil {
 			return xerrors.Errorf("failed to delete reminder item: %w", err)
 		}
-		msg := r.message.Text(prefixReminder + "リマインダーを削除しました。")
+		msg := r.message.Localize(e.Language).Text(printer(e.Language).Sprintf(prefixReminder + "リマインダーを削除しました。"))
 		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
@@ -177,7 +386,7 @@ func (r *Reminder) handleDelete(ctx context.Context, e *model.Event) error {
 
 	case strings.HasPrefix(e.Postback.Data, reminderDeletePrefix):
 		id := strings.TrimPrefix(e.Postback.Data, reminderDeletePrefix)
-		msg := r.message.ReminderDeleteConfirmation("リマインダーを削除しますか？", reminderDeleteConfirmPrefix+id)
+		msg := r.message.Localize(e.Language).ReminderDeleteConfirmation(printer(e.Language).Sprintf("リマインダーを削除しますか？"), reminderDeleteConfirmPrefix+id)
 		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
@@ -187,15 +396,281 @@ func (r *Reminder) handleDelete(ctx context.Context, e *model.Event) error {
 	return nil
 }
 
-func (r *Reminder) HandleSchedule(ctx context.Context) error {
-	items, err := r.reminder.ListAll(ctx)
+// handleSnooze handles postbacks of "Reminder#snooze#{minutes}#{item id}".
+func (r *Reminder) handleSnooze(ctx context.Context, e *model.Event) error {
+	data := strings.TrimPrefix(e.Postback.Data, reminderSnoozePrefix)
+	minutes, id, _ := strings.Cut(data, "#")
+	n, err := strconv.Atoi(minutes)
+	if err != nil || n <= 0 {
+		return xerrors.Errorf("invalid snooze minutes: %q", minutes)
+	}
+	d := time.Duration(n) * time.Minute
+
+	if _, err := r.reminder.Snooze(ctx, e.ConversationID(), model.ReminderItemID(id), d); err != nil {
+		if code.From(err) == code.NotFound {
+			return r.replyNotFound(ctx, e)
+		}
+		return xerrors.Errorf("failed to snooze reminder item: %w", err)
+	}
+
+	p := printer(e.Language)
+	msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder+"%sにもう一度リマインドします。", durationText(p, d)))
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleAck handles postbacks of "Reminder#ack#{item id}".
+func (r *Reminder) handleAck(ctx context.Context, e *model.Event) error {
+	id := strings.TrimPrefix(e.Postback.Data, reminderAckPrefix)
+	if err := r.reminder.Acknowledge(ctx, e.ConversationID(), model.ReminderItemID(id)); err != nil {
+		if code.From(err) == code.NotFound {
+			return r.replyNotFound(ctx, e)
+		}
+		return xerrors.Errorf("failed to acknowledge reminder item: %w", err)
+	}
+
+	msg := r.message.Localize(e.Language).Text(printer(e.Language).Sprintf(prefixReminder + "完了しました。"))
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleEdit handles postbacks of "Reminder#edit#[{step}#]{item id}".
+func (r *Reminder) handleEdit(ctx context.Context, e *model.Event) error {
+	data := e.Postback.Data
+	var step, id string
+	switch {
+	case strings.HasPrefix(data, reminderEditDatetimePrefix):
+		step, id = "datetime", strings.TrimPrefix(data, reminderEditDatetimePrefix)
+	case strings.HasPrefix(data, reminderEditTimePrefix):
+		step, id = "time", strings.TrimPrefix(data, reminderEditTimePrefix)
+	default:
+		id = strings.TrimPrefix(data, reminderEditPrefix)
+	}
+
+	item, err := r.reminder.Get(ctx, e.ConversationID(), model.ReminderItemID(id))
 	if err != nil {
-		return xerrors.Errorf("failed to list reminder items: %w", err)
+		if code.From(err) == code.NotFound {
+			return r.replyNotFound(ctx, e)
+		}
+		return xerrors.Errorf("failed to get reminder item: %w", err)
 	}
 
-	if err := r.reminder.SyncSchedule(ctx, items); err != nil {
+	p := printer(e.Language)
+	var scheduler model.Scheduler
+	switch step {
+	case "":
+		var msg repository.MessageProvider
+		switch item.Scheduler.(type) {
+		case *model.OneshotScheduler:
+			text := p.Sprintf(prefixReminder + "いつに変更しますか？")
+			msg = r.message.Localize(e.Language).DateTimePicker(text, reminderEditDatetimePrefix+id, time.Now().In(e.Location))
+		case *model.CronScheduler:
+			msg = r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder + "cronで設定されたリマインダーは時刻を変更できません。"))
+		default:
+			msg = r.message.Localize(e.Language).TimePicker(p.Sprintf(prefixReminder+"何時に変更しますか？"), reminderEditTimePrefix+id)
+		}
+		if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply message: %w", err)
+		}
+		return errResponseReturned
+
+	case "datetime":
+		t, err := time.ParseInLocation(datetimePickerLayout, e.Postback.Params.Datetime, e.Location)
+		if err != nil {
+			return xerrors.Errorf("failed to parse datetime: %w", err)
+		}
+		now := time.Now()
+		if !t.After(now) {
+			text := p.Sprintf(prefixReminder + "過去の日時は指定できません。\n未来の日時を選択してください。")
+			msg := r.message.Localize(e.Language).DateTimePicker(text, e.Postback.Data, now.In(e.Location))
+			if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply message: %w", err)
+			}
+			return errResponseReturned
+		}
+		scheduler = &model.OneshotScheduler{Time: t}
+
+	case "time":
+		t, err := time.Parse("15:04", e.Postback.Params.Time)
+		if err != nil {
+			return xerrors.Errorf("failed to parse time: %w", err)
+		}
+		scheduler, err = model.WithClock(item.Scheduler, t.Hour(), t.Minute())
+		if err != nil {
+			return xerrors.Errorf("failed to change time: %w", err)
+		}
+	}
+
+	item.Scheduler = scheduler
+	if err := r.reminder.Update(ctx, item); err != nil {
+		return xerrors.Errorf("failed to update reminder item: %w", err)
+	}
+
+	msg := r.message.Localize(e.Language).Text(p.Sprintf(prefixReminder+"%sに変更しました。", scheduleText(p, scheduler)))
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handlePause pauses or resumes the reminder item.
+func (r *Reminder) handlePause(ctx context.Context, e *model.Event, id string, paused bool) error {
+	item, err := r.reminder.Get(ctx, e.ConversationID(), model.ReminderItemID(id))
+	if err != nil {
+		if code.From(err) == code.NotFound {
+			return r.replyNotFound(ctx, e)
+		}
+		return xerrors.Errorf("failed to get reminder item: %w", err)
+	}
+
+	item.Paused = paused
+	if err := r.reminder.Update(ctx, item); err != nil {
+		return xerrors.Errorf("failed to update reminder item: %w", err)
+	}
+
+	p := printer(e.Language)
+	text := p.Sprintf(prefixReminder+"%sのリマインドを再開しました。", executorText(p, item.Executor))
+	if paused {
+		text = p.Sprintf(prefixReminder+"%sのリマインドを一時停止しました。", executorText(p, item.Executor))
+	}
+	if err := r.bot.ReplyMessage(ctx, e, r.message.Localize(e.Language).Text(text)); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+func (r *Reminder) replyNotFound(ctx context.Context, e *model.Event) error {
+	msg := r.message.Localize(e.Language).Text(printer(e.Language).Sprintf(prefixReminder + "リマインダーが見つかりませんでした。"))
+	if err := r.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+func (r *Reminder) HandleSchedule(ctx context.Context) error {
+	if err := r.reminder.SyncSchedule(ctx); err != nil {
 		return xerrors.Errorf("failed to sync schedule: %w", err)
 	}
 
 	return nil
 }
+
+func (r *Reminder) HandleReminder(ctx context.Context, item *model.ReminderItem) error {
+	if item.Executor.Type != model.ExecutorTypeMessage {
+		return nil
+	}
+
+	lang, err := r.conversation.Language(ctx, item.ConversationID)
+	if err != nil {
+		return xerrors.Errorf("failed to get language: %w", err)
+	}
+	set := r.message.Localize(lang)
+	msg := set.ReminderActions(set.Text(printer(lang).Sprintf("【リマインド】\n%s", item.Executor.Payload)), item.ID)
+	if err := r.bot.PushMessage(ctx, item.ConversationID, msg); err != nil {
+		return xerrors.Errorf("failed to push message: %w", err)
+	}
+
+	return nil
+}
+
+// setShoppingList sets the list to remind as the payload of the executor and returns the text for UI,
+// a named list in the subject is preferred to the current list.
+func (r *Reminder) setShoppingList(ctx context.Context, p *message.Printer, conversationID model.ConversationID, executor *model.Executor, subject string) (string, error) {
+	lists, err := r.shopping.Lists(ctx, conversationID)
+	if err != nil {
+		return "", xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	var list *model.ShoppingList
+	for _, l := range lists {
+		if !l.IsDefault() && strings.Contains(subject, l.Name) {
+			list = l
+			break
+		}
+	}
+	if list == nil {
+		list, err = r.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return "", xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+	}
+
+	executor.Payload = string(list.ID)
+	if list.IsDefault() {
+		return executorText(p, executor), nil
+	}
+	return executorText(p, executor) + p.Sprintf("「%s」", list.Name), nil
+}
+
+func executorFromSubject(subject string) *model.Executor {
+	switch {
+	case strings.Contains(subject, model.ExecutorTypeShoppingList.UIText()):
+		return &model.Executor{Type: model.ExecutorTypeShoppingList}
+	case strings.Contains(subject, model.ExecutorTypeWeather.UIText()):
+		return &model.Executor{Type: model.ExecutorTypeWeather}
+	case subject != "":
+		return &model.Executor{Type: model.ExecutorTypeMessage, Payload: subject}
+	default:
+		return nil
+	}
+}
+
+// executorText returns the text of the executor such as "買い物リスト", the message to remind is not translated.
+func executorText(p *message.Printer, e *model.Executor) string {
+	if e.Type == model.ExecutorTypeMessage && e.Payload != "" {
+		return e.Payload
+	}
+	return p.Sprintf(e.Type.UIText())
+}
+
+// durationText returns a text such as "10分後" or "1時間後".
+func durationText(p *message.Printer, d time.Duration) string {
+	if d%time.Hour == 0 {
+		return p.Sprintf("%d時間後", int(d/time.Hour))
+	}
+	return p.Sprintf("%d分後", int(d/time.Minute))
+}
+
+// exclusionText returns a text of the exclusion such as "（祝日を除く）".
+func exclusionText(p *message.Printer, e *model.Exclusion) string {
+	if e.Empty() {
+		return ""
+	}
+	texts := make([]string, 0, len(e.Ranges)+1)
+	switch {
+	case e.BusinessDays:
+		texts = append(texts, p.Sprintf("営業日のみ"))
+	case e.Holidays:
+		texts = append(texts, p.Sprintf("祝日を除く"))
+	}
+	for _, r := range e.Ranges {
+		texts = append(texts, p.Sprintf("%s〜%sを除く", r.Start.Format("1/2"), r.End.Format("1/2")))
+	}
+	return p.Sprintf("（%s）", strings.Join(texts, p.Sprintf("、")))
+}
+
+//nolint:gochecknoglobals
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// scheduleText returns a text of the schedule, the weekdays are the keys of the catalogue.
+func scheduleText(p *message.Printer, s model.Scheduler) string {
+	switch s := s.(type) {
+	case *model.OneshotScheduler:
+		return s.Time.Format("2006/01/02 15:04")
+	case *model.DailyScheduler:
+		return p.Sprintf("毎日%s", s.Time.Format("15:04"))
+	case *model.WeeklyScheduler:
+		weekdays := make([]string, 0, len(s.Weekdays))
+		for _, wd := range s.Weekdays {
+			weekdays = append(weekdays, p.Sprintf(japaneseWeekdays[wd]))
+		}
+		return p.Sprintf("毎週%s曜%s", strings.Join(weekdays, p.Sprintf("・")), s.Time.Format("15:04"))
+	case *model.MonthlyScheduler:
+		return p.Sprintf("毎月%d日%s", s.Day, s.Time.Format("15:04"))
+	default:
+		return s.UIText()
+	}
+}
@@ -0,0 +1,186 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/domain/service"
+)
+
+const (
+	triggerTimezone        = "タイムゾーン"
+	triggerTimezoneEnglish = "timezone"
+	triggerLanguage        = "言語"
+	triggerLanguageEnglish = "language"
+	triggerWord            = "単語登録"
+	prefixSetting          = "【設定】"
+)
+
+type Setting struct {
+	conversation service.Conversation
+	dictionary   service.Dictionary
+	message      repository.MessageProviderSet
+	bot          service.Bot
+}
+
+func NewSetting(
+	conversation service.Conversation,
+	dictionary service.Dictionary,
+	message repository.MessageProviderSet,
+	bot service.Bot,
+) *Setting {
+	return &Setting{
+		conversation: conversation,
+		dictionary:   dictionary,
+		message:      message,
+		bot:          bot,
+	}
+}
+
+func (s *Setting) Handle(ctx context.Context, e *model.Event) error {
+	err := e.HandleTypeMessage(ctx, func(context.Context, *model.Event) error {
+		lines := e.ReadTextLines()
+		// a text such as "言語 English" is an input of the flow in progress
+		if len(lines) == 0 || e.Status.InputPending() {
+			return nil
+		}
+		if arg, ok := cutTrigger(lines[0], triggerTimezone, triggerTimezoneEnglish); ok {
+			return s.handleTimezone(ctx, e, strings.TrimSpace(arg))
+		}
+		if arg, ok := cutTrigger(lines[0], triggerLanguage, triggerLanguageEnglish); ok {
+			return s.handleLanguage(ctx, e, strings.TrimSpace(arg))
+		}
+		if arg, ok := cutTrigger(lines[0], triggerWord); ok {
+			return s.handleWord(ctx, e, strings.Fields(arg))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to handle type message: %w", err)
+	}
+
+	return nil
+}
+
+// handleTimezone shows the time zone of the conversation or sets it by "タイムゾーン America/New_York".
+func (s *Setting) handleTimezone(ctx context.Context, e *model.Event, name string) error {
+	p := printer(e.Language)
+	if name == "" {
+		text := p.Sprintf(prefixSetting+"タイムゾーンは%sです。\n変更する場合は「タイムゾーン America/New_York」のように入力してください。",
+			e.Location.String())
+		if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+
+	loc, err := s.conversation.SetTimezone(ctx, e.ConversationID(), name)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidTimezone) {
+			text := p.Sprintf(prefixSetting+"%sは不明なタイムゾーンです。\n「Asia/Tokyo」のように入力してください。", name)
+			if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
+		}
+		return xerrors.Errorf("failed to set timezone: %w", err)
+	}
+
+	text := p.Sprintf(prefixSetting+"タイムゾーンを%sに変更しました。", loc.String())
+	if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleWord shows the words of the conversation or teaches a word by "単語登録 オイコス おいこす".
+func (s *Setting) handleWord(ctx context.Context, e *model.Event, args []string) error {
+	p := printer(e.Language)
+	usage := p.Sprintf("登録する場合は「単語登録 オイコス おいこす」のように単語と読みを入力してください。")
+	if len(args) != 2 {
+		words, err := s.dictionary.Words(ctx, e.ConversationID())
+		if err != nil {
+			return xerrors.Errorf("failed to get words: %w", err)
+		}
+		text := p.Sprintf(prefixSetting+"登録された単語はありません。\n%s", usage)
+		if len(words) > 0 {
+			text = p.Sprintf(prefixSetting+"登録された単語は次の通りです。\n%s\n\n%s", words.Print(), usage)
+		}
+		if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+
+	word, err := s.dictionary.AddWord(ctx, e.ConversationID(), args[0], args[1])
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidUserWord) {
+			text := p.Sprintf(prefixSetting+"単語を登録できませんでした。\n読みはひらがなかカタカナで入力してください。\n%s", usage)
+			if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
+		}
+		return xerrors.Errorf("failed to add word: %w", err)
+	}
+
+	text := p.Sprintf(prefixSetting+"「%s（%s）」を登録しました。", word.Surface, word.Reading)
+	if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// handleLanguage shows the language of the conversation or sets it by "言語 English".
+func (s *Setting) handleLanguage(ctx context.Context, e *model.Event, name string) error {
+	p := printer(e.Language)
+	if name == "" {
+		text := p.Sprintf(prefixSetting+"言語は%sです。\n変更する場合は「言語 English」のように入力してください。", e.Language.Name())
+		if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+
+	lang, err := model.ParseLanguage(name)
+	if err != nil {
+		text := p.Sprintf(prefixSetting+"%sは不明な言語です。\n「日本語」か「English」を入力してください。", name)
+		if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+	if err := s.conversation.SetLanguage(ctx, e.ConversationID(), lang); err != nil {
+		return xerrors.Errorf("failed to set language: %w", err)
+	}
+
+	// the reply is in the new language
+	text := printer(lang).Sprintf(prefixSetting+"言語を%sに変更しました。", lang.Name())
+	if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(lang).Text(text)); err != nil {
+		return xerrors.Errorf("failed to reply text message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// cutTrigger returns the text after one of the triggers, the triggers are compared regardless of the case.
+// A trigger must be followed by a space or the end of the line, "言語学の本" is not a trigger.
+func cutTrigger(line string, triggers ...string) (string, bool) {
+	for _, trigger := range triggers {
+		if len(line) < len(trigger) || !strings.EqualFold(line[:len(trigger)], trigger) {
+			continue
+		}
+		rest := line[len(trigger):]
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || unicode.IsSpace(r) {
+			return rest, true
+		}
+	}
+	return "", false
+}
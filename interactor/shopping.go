@@ -3,23 +3,38 @@ package interactor
 import (
 	"context"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
+	"golang.org/x/text/message"
 	"golang.org/x/xerrors"
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
 	"github.com/ww24/linebot/domain/service"
+	"github.com/ww24/linebot/internal/code"
 )
 
 const (
-	triggerShopping = "買い物リスト"
-	prefixShopping  = "【買い物リスト】"
+	triggerShopping       = "買い物リスト"
+	triggerShoppingRename = "買い物リスト名"
+	triggerShoppingUsual  = "いつもの"
+	// triggerShoppingUsualEnglish is compared regardless of the case.
+	triggerShoppingUsualEnglish = "usual"
+	prefixShopping              = "【買い物リスト】"
+
+	shoppingListSwitchPrefix        = "Shopping#list#switch#"
+	shoppingListDeletePrefix        = "Shopping#list#delete#"
+	shoppingListDeleteConfirmPrefix = "Shopping#list#delete#confirm#"
+	shoppingUsualAddPrefix          = "Shopping#usual#add#"
+	shoppingUndoPrefix              = "Shopping#undo#"
+	shoppingDeleteItemsPrefix       = "Shopping#delete#items#"
 )
 
-var errItemNotFound = errors.New("item not found")
+var (
+	errItemNotFound      = errors.New("item not found")
+	errItemNotIdentified = errors.New("item not identified")
+)
 
 type Shopping struct {
 	conversation service.Conversation
@@ -47,8 +62,19 @@ func NewShopping(
 
 func (s *Shopping) Handle(ctx context.Context, e *model.Event) error {
 	err := e.HandleTypeMessage(ctx, func(ctx context.Context, e *model.Event) error {
-		if e.FilterText(triggerShopping) {
-			return s.handleMenu(ctx, e)
+		switch e.IntentType() {
+		case model.IntentTypeShowList:
+			return s.handleTrigger(ctx, e)
+		case model.IntentTypeAddItems:
+			return s.addItems(ctx, e, e.Intent.Items)
+		case model.IntentTypeDelete, model.IntentTypeCheck, model.IntentTypeUpdate:
+			// "牛乳を削除" works without opening the list unless a flow waits for a text
+			if !e.Status.InputPending() {
+				return s.handleMessageAction(ctx, e, e.Intent.Item)
+			}
+		}
+		if text := strings.Join(e.ReadTextLines(), ""); text == triggerShoppingUsual || strings.EqualFold(text, triggerShoppingUsualEnglish) {
+			return s.handleUsual(ctx, e)
 		}
 
 		return s.handleStatus(ctx, e)
@@ -64,29 +90,68 @@ func (s *Shopping) Handle(ctx context.Context, e *model.Event) error {
 	return nil
 }
 
+func (s *Shopping) menuMessage(e *model.Event, text string, rt model.ShoppingReplyType, snapshot *model.ShoppingSnapshot) repository.MessageProvider {
+	if snapshot == nil {
+		return s.message.Localize(e.Language).ShoppingMenu(text, rt)
+	}
+	return s.message.Localize(e.Language).ShoppingUndo(text, rt, snapshot.ID)
+}
+
+// handleTrigger handles "買い物リスト", "買い物リスト {list name}" and "買い物リスト名 {new name}".
+func (s *Shopping) handleTrigger(ctx context.Context, e *model.Event) error {
+	fields := strings.Fields(strings.Join(e.ReadTextLines(), " "))
+	if len(fields) < 2 {
+		return s.handleMenu(ctx, e)
+	}
+	name := strings.Join(fields[1:], " ")
+
+	switch fields[0] {
+	case triggerShopping:
+		return s.switchListByName(ctx, e, name)
+	case triggerShoppingRename:
+		return s.renameList(ctx, e, name)
+	default:
+		return s.handleMenu(ctx, e)
+	}
+}
+
 func (s *Shopping) handleMenu(ctx context.Context, e *model.Event, texts ...string) error {
-	items, err := s.shopping.List(ctx, e.ConversationID())
+	return s.replyMenu(ctx, e, nil, texts...)
+}
+
+// replyMenu replies the menu of the current list, it offers to undo the deletion if the snapshot is not nil.
+func (s *Shopping) replyMenu(ctx context.Context, e *model.Event, snapshot *model.ShoppingSnapshot, texts ...string) error {
+	list, err := s.shopping.CurrentList(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items, err := s.shopping.List(ctx, e.ConversationID(), list.ID)
 	if err != nil {
 		return xerrors.Errorf("failed to list shopping items: %w", err)
 	}
 
-	prefixMsg := prefixShopping
+	p := printer(e.Language)
+	prefixMsg := shoppingPrefix(p, list)
 	if len(texts) > 0 {
 		prefixMsg += strings.Join(texts, "\n") + "\n\n"
 	}
 
 	if len(items) == 0 {
-		text := prefixMsg + "リストは空です。\n何をしますか？"
-		msg := s.message.ShoppingMenu(text, model.ShoppingReplyTypeEmptyList)
+		text := prefixMsg + p.Sprintf("リストは空です。\n何をしますか？")
+		msg := s.menuMessage(e, text, model.ShoppingReplyTypeEmptyList, snapshot)
 		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
 		return errResponseReturned
 	}
 
-	text := fmt.Sprintf(prefixMsg+"%d件登録されています。\n%s\n\n何をしますか？",
-		len(items), items.Print(model.ListTypeOrdered))
-	msg := s.message.ShoppingMenu(text, model.ShoppingReplyTypeWithoutView)
+	text := prefixMsg + p.Sprintf("%d件登録されています。\n%s\n\n何をしますか？",
+		len(items), items.Print(model.ListTypeOrdered, translator(p)))
+	rt := model.ShoppingReplyTypeWithoutView
+	if len(items.Checked()) > 0 {
+		rt = model.ShoppingReplyTypeWithChecked
+	}
+	msg := s.menuMessage(e, text, rt, snapshot)
 	if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 		return xerrors.Errorf("failed to reply message: %w", err)
 	}
@@ -96,21 +161,27 @@ func (s *Shopping) handleMenu(ctx context.Context, e *model.Event, texts ...stri
 
 func (s *Shopping) handlePostBack(ctx context.Context, e *model.Event) error {
 	conversationID := e.ConversationID()
+	p := printer(e.Language)
 
 	switch e.Postback.Data {
 	case "Shopping#delete":
-		text := prefixShopping + "リストを空にしても良いですか？"
-		msg := s.message.ShoppingDeleteConfirmation(text)
+		text := p.Sprintf(prefixShopping + "リストを空にしても良いですか？")
+		msg := s.message.Localize(e.Language).ShoppingDeleteConfirmation(text)
 		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
 		return errResponseReturned
 
 	case "Shopping#deleteConfirm":
-		if err := s.shopping.DeleteAllItem(ctx, conversationID); err != nil {
+		list, err := s.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+		snapshot, err := s.shopping.DeleteAllItem(ctx, conversationID, list.ID)
+		if err != nil {
 			return xerrors.Errorf("failed to delete all shopping items: %w", err)
 		}
-		if err := s.handleMenu(ctx, e); err != nil {
+		if err := s.replyMenu(ctx, e, snapshot); err != nil {
 			return err
 		}
 		return nil
@@ -124,6 +195,21 @@ func (s *Shopping) handlePostBack(ctx context.Context, e *model.Event) error {
 		}
 		return nil
 
+	case "Shopping#clearChecked":
+		list, err := s.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+		deleted, err := s.shopping.DeleteCheckedItems(ctx, conversationID, list.ID)
+		if err != nil {
+			return xerrors.Errorf("failed to delete checked shopping items: %w", err)
+		}
+		text := p.Sprintf("購入済みの商品を%d件削除しました。", len(deleted))
+		if err := s.handleMenu(ctx, e, text); err != nil {
+			return err
+		}
+		return nil
+
 	case "Shopping#add":
 		status := &model.ConversationStatus{
 			ConversationID: conversationID,
@@ -132,20 +218,107 @@ func (s *Shopping) handlePostBack(ctx context.Context, e *model.Event) error {
 		if err := s.conversation.SetStatus(ctx, status); err != nil {
 			return xerrors.Errorf("failed to set status: %w", err)
 		}
-		msg := s.message.Text(prefixShopping + "追加する商品を1行に1つずつ入力してください。")
+		msg := s.message.Localize(e.Language).Prompt(p.Sprintf(prefixShopping + "追加する商品を1行に1つずつ入力してください。"))
 		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply text message: %w", err)
 		}
 		return errResponseReturned
 
 	case "Shopping#view":
-		items, err := s.shopping.List(ctx, conversationID)
+		list, err := s.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+		items, err := s.shopping.List(ctx, conversationID, list.ID)
 		if err != nil {
 			return xerrors.Errorf("failed to list shopping items: %w", err)
 		}
 
-		text := prefixShopping + "\n" + items.Print(model.ListTypeOrdered)
-		msg := s.message.ShoppingMenu(text, model.ShoppingReplyTypeWithoutView)
+		text := shoppingPrefix(p, list) + "\n" + items.Print(model.ListTypeOrdered, translator(p))
+		msg := s.message.Localize(e.Language).ShoppingMenu(text, model.ShoppingReplyTypeWithoutView)
+		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply message: %w", err)
+		}
+		return errResponseReturned
+
+	case "Shopping#usual":
+		return s.handleUsual(ctx, e)
+
+	case "Shopping#usual#addAll":
+		list, err := s.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+		suggestions, err := s.shopping.Suggest(ctx, conversationID, list.ID)
+		if err != nil {
+			return xerrors.Errorf("failed to suggest shopping items: %w", err)
+		}
+		items := make(model.ShoppingItems, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			items = append(items, suggestion.Item)
+		}
+		return s.addUsual(ctx, e, list, items)
+
+	case "Shopping#lists":
+		return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"どのリストを使いますか？"))
+
+	case "Shopping#list#add":
+		status := &model.ConversationStatus{
+			ConversationID: conversationID,
+			Type:           model.ConversationStatusTypeShoppingListAdd,
+		}
+		if err := s.conversation.SetStatus(ctx, status); err != nil {
+			return xerrors.Errorf("failed to set status: %w", err)
+		}
+		msg := s.message.Localize(e.Language).Prompt(p.Sprintf(prefixShopping + "新しいリストの名前を入力してください。"))
+		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+			return xerrors.Errorf("failed to reply text message: %w", err)
+		}
+		return errResponseReturned
+	}
+
+	switch {
+	case strings.HasPrefix(e.Postback.Data, shoppingDeleteItemsPrefix):
+		ids := strings.Split(strings.TrimPrefix(e.Postback.Data, shoppingDeleteItemsPrefix), ",")
+		return s.deleteChosenItems(ctx, e, ids)
+	case strings.HasPrefix(e.Postback.Data, shoppingUndoPrefix):
+		id := model.ShoppingSnapshotID(strings.TrimPrefix(e.Postback.Data, shoppingUndoPrefix))
+		snapshot, err := s.shopping.Undo(ctx, conversationID, id)
+		if errors.Is(err, model.ErrShoppingSnapshotExpired) {
+			return s.handleMenu(ctx, e, p.Sprintf("元に戻せませんでした。\n削除してから%d分以内に操作してください。",
+				int(model.ShoppingUndoWindow/time.Minute)))
+		}
+		if err != nil {
+			return xerrors.Errorf("failed to undo: %w", err)
+		}
+		text := p.Sprintf("次の商品を元に戻しました。\n%s", snapshot.Items.Print(model.ListTypeDotted, translator(p)))
+		return s.handleMenu(ctx, e, text)
+	case strings.HasPrefix(e.Postback.Data, shoppingUsualAddPrefix):
+		list, err := s.shopping.CurrentList(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to get current shopping list: %w", err)
+		}
+		name, quantity, unit := s.nlParser.ParseQuantity(strings.TrimPrefix(e.Postback.Data, shoppingUsualAddPrefix))
+		item := &model.ShoppingItem{Name: name, Quantity: quantity, Unit: unit}
+		return s.addUsual(ctx, e, list, model.ShoppingItems{item})
+	case strings.HasPrefix(e.Postback.Data, shoppingListSwitchPrefix):
+		id := model.ShoppingListID(strings.TrimPrefix(e.Postback.Data, shoppingListSwitchPrefix))
+		return s.switchList(ctx, e, id)
+	case strings.HasPrefix(e.Postback.Data, shoppingListDeleteConfirmPrefix):
+		id := model.ShoppingListID(strings.TrimPrefix(e.Postback.Data, shoppingListDeleteConfirmPrefix))
+		return s.deleteList(ctx, e, id)
+	case strings.HasPrefix(e.Postback.Data, shoppingListDeletePrefix):
+		id := model.ShoppingListID(strings.TrimPrefix(e.Postback.Data, shoppingListDeletePrefix))
+		lists, err := s.shopping.Lists(ctx, conversationID)
+		if err != nil {
+			return xerrors.Errorf("failed to list shopping lists: %w", err)
+		}
+		list, err := lists.Get(id)
+		if err != nil {
+			return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"リストが見つかりませんでした。"))
+		}
+		text := p.Sprintf(prefixShopping+"「%s」と登録されている商品を削除しても良いですか？", list.Name)
+		msg := s.message.Localize(e.Language).ShoppingListDeleteConfirmation(text, list.ID)
 		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 			return xerrors.Errorf("failed to reply message: %w", err)
 		}
@@ -155,42 +328,186 @@ func (s *Shopping) handlePostBack(ctx context.Context, e *model.Event) error {
 	return nil
 }
 
+// deleteChosenItems deletes the items chosen from the candidates of deleteFromItem.
+func (s *Shopping) deleteChosenItems(ctx context.Context, e *model.Event, ids []string) error {
+	conversationID := e.ConversationID()
+	list, err := s.shopping.CurrentList(ctx, conversationID)
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items, err := s.shopping.List(ctx, conversationID, list.ID)
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping items: %w", err)
+	}
+	targets := items.FilterByIDs(ids)
+	if len(targets) == 0 {
+		return s.handleMenu(ctx, e, printer(e.Language).Sprintf("削除する商品が見つかりませんでした。"))
+	}
+
+	deleted, snapshot, err := s.deleteItems(ctx, conversationID, list.ID, targets)
+	if err != nil {
+		return err
+	}
+	p := printer(e.Language)
+	text := p.Sprintf("次の商品を削除しました。\n%s", deleted.Print(model.ListTypeDotted, translator(p)))
+	return s.replyMenu(ctx, e, snapshot, text)
+}
+
+// handleUsual suggests the usual items which are not on the current list.
+func (s *Shopping) handleUsual(ctx context.Context, e *model.Event) error {
+	list, err := s.shopping.CurrentList(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	suggestions, err := s.shopping.Suggest(ctx, e.ConversationID(), list.ID)
+	if err != nil {
+		return xerrors.Errorf("failed to suggest shopping items: %w", err)
+	}
+
+	p := printer(e.Language)
+	text := shoppingPrefix(p, list) + p.Sprintf("いつもの商品はまだありません。\n何度か買った商品をおすすめします。")
+	if len(suggestions) > 0 {
+		text = shoppingPrefix(p, list) + p.Sprintf("いつもの商品はこちらです。\nタップするとリストに追加します。\n%s", suggestions.Print(translator(p)))
+	}
+	msg := s.message.Localize(e.Language).ShoppingSuggestions(text, suggestions)
+	if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
+}
+
+// addUsual adds the suggested items to the list.
+func (s *Shopping) addUsual(ctx context.Context, e *model.Event, list *model.ShoppingList, templates model.ShoppingItems) error {
+	if len(templates) == 0 {
+		return s.handleMenu(ctx, e, printer(e.Language).Sprintf("追加する商品がありませんでした。"))
+	}
+
+	now := time.Now()
+	items := make([]*model.ShoppingItem, 0, len(templates))
+	for i, t := range templates {
+		item := model.NewShoppingItem(e.ConversationID(), list.ID, t.Name, t.Quantity, t.Unit, i, now)
+		item.Category = s.nlParser.Category(t.Name)
+		items = append(items, item)
+	}
+	added, err := s.shopping.AddItem(ctx, e.ConversationID(), list.ID, items...)
+	if err != nil {
+		return xerrors.Errorf("failed to add item: %w", err)
+	}
+	p := printer(e.Language)
+	return s.handleMenu(ctx, e, p.Sprintf("次の商品を追加しました。\n%s", added.Print(model.ListTypeDotted, translator(p))))
+}
+
+func (s *Shopping) replyLists(ctx context.Context, e *model.Event, text string) error {
+	lists, err := s.shopping.Lists(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	current, err := s.shopping.CurrentList(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+
+	msg := s.message.Localize(e.Language).ShoppingLists(text, lists, current.ID)
+	if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
+}
+
+func (s *Shopping) switchList(ctx context.Context, e *model.Event, id model.ShoppingListID) error {
+	lists, err := s.shopping.Lists(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	p := printer(e.Language)
+	list, err := lists.Get(id)
+	if err != nil {
+		return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"リストが見つかりませんでした。"))
+	}
+	if err := s.shopping.SwitchList(ctx, e.ConversationID(), list.ID); err != nil {
+		return xerrors.Errorf("failed to switch shopping list: %w", err)
+	}
+	return s.handleMenu(ctx, e, p.Sprintf("「%s」に切り替えました。", list.Name))
+}
+
+func (s *Shopping) switchListByName(ctx context.Context, e *model.Event, name string) error {
+	lists, err := s.shopping.Lists(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	list, err := lists.FindByName(name)
+	if err != nil {
+		return s.replyLists(ctx, e, printer(e.Language).Sprintf(prefixShopping+"「%s」というリストは見つかりませんでした。", name))
+	}
+	return s.switchList(ctx, e, list.ID)
+}
+
+func (s *Shopping) renameList(ctx context.Context, e *model.Event, name string) error {
+	list, err := s.shopping.CurrentList(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	p := printer(e.Language)
+	if list.IsDefault() {
+		return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"「%s」の名前は変更できません。", list.Name))
+	}
+	lists, err := s.shopping.Lists(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	if _, err := lists.FindByName(name); err == nil {
+		return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"「%s」というリストは既にあります。", name))
+	}
+	if err := s.shopping.RenameList(ctx, list, name); err != nil {
+		return xerrors.Errorf("failed to rename shopping list: %w", err)
+	}
+	return s.handleMenu(ctx, e, p.Sprintf("「%s」を「%s」に変更しました。", list.Name, name))
+}
+
+func (s *Shopping) deleteList(ctx context.Context, e *model.Event, id model.ShoppingListID) error {
+	lists, err := s.shopping.Lists(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	p := printer(e.Language)
+	list, err := lists.Get(id)
+	if err != nil || list.IsDefault() {
+		return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"リストが見つかりませんでした。"))
+	}
+	if err := s.shopping.DeleteList(ctx, e.ConversationID(), list.ID); err != nil {
+		return xerrors.Errorf("failed to delete shopping list: %w", err)
+	}
+	return s.handleMenu(ctx, e, p.Sprintf("「%s」を削除しました。", list.Name))
+}
+
 func (s *Shopping) handleStatus(ctx context.Context, e *model.Event) error {
 	switch e.Status.Type {
 	case model.ConversationStatusTypeShopping:
-		itemText := strings.Join(e.ReadTextLines(), " ")
-		// parse message text
-		item := s.nlParser.Parse(itemText)
-		if err := s.handleMessageAction(ctx, e, item); err != nil {
+		if err := s.handleMessageAction(ctx, e, e.Intent.Item); err != nil {
 			return xerrors.Errorf("failed to handle message action: %w", err)
 		}
 		return nil
 
 	case model.ConversationStatusTypeShoppingAdd:
-		lines := e.ReadTextLines()
-		items := make([]*model.ShoppingItem, 0, len(lines))
-		for i, line := range lines {
-			item := model.NewShoppingItem(
-				e.ConversationID(),
-				line,
-				1,
-				i,
-				time.Now(),
-			)
-			items = append(items, item)
-		}
-		if err := s.shopping.AddItem(ctx, e.ConversationID(), items...); err != nil {
-			return xerrors.Errorf("failed to add item: %w", err)
-		}
-
-		text := fmt.Sprintf(prefixShopping+"%d件追加されました。", len(lines))
-		msg := s.message.ShoppingMenu(text, model.ShoppingReplyTypeAll)
-		if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
-			return xerrors.Errorf("failed to reply message: %w", err)
+		return s.addItems(ctx, e, e.ReadTextLines())
+
+	case model.ConversationStatusTypeShoppingListAdd:
+		name := strings.Join(e.ReadTextLines(), " ")
+		if name == "" {
+			return nil
 		}
-		return errResponseReturned
+		p := printer(e.Language)
+		list, err := s.shopping.CreateList(ctx, e.ConversationID(), name)
+		if code.From(err) == code.AlreadyExists {
+			return s.replyLists(ctx, e, p.Sprintf(prefixShopping+"「%s」というリストは既にあります。", name))
+		}
+		if err != nil {
+			return xerrors.Errorf("failed to create shopping list: %w", err)
+		}
+		return s.handleMenu(ctx, e, p.Sprintf("「%s」を作成しました。", list.Name))
 
-	case model.ConversationStatusTypeReminderAdd:
+	case model.ConversationStatusTypeReminderAdd,
+		model.ConversationStatusTypeReminderAddMessage:
 		// do nothing
 		return nil
 
@@ -204,13 +521,111 @@ func (s *Shopping) handleStatus(ctx context.Context, e *model.Event) error {
 	}
 }
 
+// addItems adds the items of the lines such as "卵 2パック" to the current list.
+func (s *Shopping) addItems(ctx context.Context, e *model.Event, lines []string) error {
+	list, err := s.shopping.CurrentList(ctx, e.ConversationID())
+	if err != nil {
+		return xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items := make([]*model.ShoppingItem, 0, len(lines))
+	for i, line := range lines {
+		name, quantity, unit := s.nlParser.ParseQuantity(line)
+		item := model.NewShoppingItem(
+			e.ConversationID(),
+			list.ID,
+			name,
+			quantity,
+			unit,
+			i,
+			time.Now(),
+		)
+		item.Category = s.nlParser.Category(name)
+		items = append(items, item)
+	}
+	added, err := s.shopping.AddItem(ctx, e.ConversationID(), list.ID, items...)
+	if err != nil {
+		return xerrors.Errorf("failed to add item: %w", err)
+	}
+
+	p := printer(e.Language)
+	text := shoppingPrefix(p, list) + p.Sprintf("%d件追加されました。\n%s", len(lines), added.Print(model.ListTypeDotted, translator(p)))
+	msg := s.message.Localize(e.Language).ShoppingMenu(text, model.ShoppingReplyTypeAll)
+	if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+		return xerrors.Errorf("failed to reply message: %w", err)
+	}
+	return errResponseReturned
+}
+
 func (s *Shopping) handleMessageAction(ctx context.Context, e *model.Event, item *model.Item) error {
+	p := printer(e.Language)
 	switch item.Action {
 	case model.ActionTypeDelete:
-		foundItems, err := s.deleteFromItem(ctx, e.ConversationID(), item)
+		// "全部削除" asks for the confirmation as the button does
+		if item.All {
+			msg := s.message.Localize(e.Language).ShoppingDeleteConfirmation(p.Sprintf(prefixShopping + "リストを空にしても良いですか？"))
+			if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply message: %w", err)
+			}
+			return errResponseReturned
+		}
+		foundItems, snapshot, err := s.deleteFromItem(ctx, e.ConversationID(), item)
+		if err != nil {
+			var msg repository.MessageProvider
+			switch {
+			case errors.Is(err, errItemNotFound):
+				msg = s.message.Localize(e.Language).Text(p.Sprintf("削除する商品が見つかりませんでした。\n削除する場合は「○番を削除」や「牛乳を削除」と入力してみて下さい。"))
+			case errors.Is(err, errItemNotIdentified):
+				text := p.Sprintf("次の商品が見つかりました。\n削除する商品を選んで下さい。\n%s", foundItems.Print(model.ListTypeDotted, translator(p)))
+				msg = s.message.Localize(e.Language).ShoppingDeleteChoices(text, foundItems)
+			default:
+				return err
+			}
+			if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply message: %w", err)
+			}
+			return errResponseReturned
+		}
+		text := p.Sprintf("次の商品を削除しました。\n%s", foundItems.Print(model.ListTypeDotted, translator(p)))
+		if err := s.replyMenu(ctx, e, snapshot, text); err != nil {
+			return err
+		}
+		return errResponseReturned
+
+	case model.ActionTypeUpdate:
+		if item.NewName == "" {
+			msg := s.message.Localize(e.Language).Text(p.Sprintf("変更後の名前が見つかりませんでした。\n「2番をバター無塩に変更」のように入力してみて下さい。"))
+			if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
+		}
+		before, after, err := s.updateFromItem(ctx, e.ConversationID(), item)
+		if err != nil {
+			var text string
+			switch {
+			case errors.Is(err, errItemNotFound):
+				text = p.Sprintf("変更する商品が見つかりませんでした。\n「2番をバター無塩に変更」のように入力してみて下さい。")
+			case errors.Is(err, errItemNotIdentified):
+				text = p.Sprintf("変更する商品が複数見つかりました。\n商品は1つずつ番号で指定して下さい。")
+			default:
+				return err
+			}
+			if err := s.bot.ReplyMessage(ctx, e, s.message.Localize(e.Language).Text(text)); err != nil {
+				return xerrors.Errorf("failed to reply text message: %w", err)
+			}
+			return errResponseReturned
+		}
+		text := p.Sprintf("「%s」を「%s」に変更しました。", before, after)
+		if err := s.handleMenu(ctx, e, text); err != nil {
+			return err
+		}
+		return errResponseReturned
+
+	case model.ActionTypeCheck:
+		checkedItems, err := s.checkFromItem(ctx, e.ConversationID(), item)
 		if err != nil {
 			if errors.Is(err, errItemNotFound) {
-				msg := s.message.Text("削除する商品が見つかりませんでした。\n削除する場合は「○番を削除」と入力してみて下さい。")
+				msg := s.message.Localize(e.Language).Text(p.Sprintf("購入済みにする商品が見つかりませんでした。\n「○番買った」と入力してみて下さい。"))
 				if err := s.bot.ReplyMessage(ctx, e, msg); err != nil {
 					return xerrors.Errorf("failed to reply text message: %w", err)
 				}
@@ -219,7 +634,7 @@ func (s *Shopping) handleMessageAction(ctx context.Context, e *model.Event, item
 
 			return err
 		}
-		text := "次の商品を削除しました。\n" + foundItems.Print(model.ListTypeDotted)
+		text := p.Sprintf("次の商品を購入済みにしました。\n%s", checkedItems.Print(model.ListTypeDotted, translator(p)))
 		if err := s.handleMenu(ctx, e, text); err != nil {
 			return err
 		}
@@ -234,36 +649,161 @@ func (s *Shopping) handleMessageAction(ctx context.Context, e *model.Event, item
 	}
 }
 
-func (s *Shopping) deleteFromItem(ctx context.Context, conversationID model.ConversationID, item *model.Item) (model.ShoppingItems, error) {
-	items, err := s.shopping.List(ctx, conversationID)
+// deleteFromItem deletes the items specified by the indexes or the names,
+// it returns the candidates with errItemNotIdentified if a name matches several items or a part of an item name.
+func (s *Shopping) deleteFromItem(ctx context.Context, conversationID model.ConversationID, item *model.Item) (model.ShoppingItems, *model.ShoppingSnapshot, error) {
+	list, err := s.shopping.CurrentList(ctx, conversationID)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to list shopping items: %w", err)
+		return nil, nil, xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items, err := s.shopping.List(ctx, conversationID, list.ID)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to list shopping items: %w", err)
+	}
+
+	targets := make(model.ShoppingItems, 0)
+	found := make(map[string]struct{})
+	appendTarget := func(item *model.ShoppingItem) {
+		if _, ok := found[item.ID]; ok {
+			return
+		}
+		found[item.ID] = struct{}{}
+		targets = append(targets, item)
+	}
+	for _, idx := range item.ResolveIndexes(len(items)) {
+		appendTarget(items[idx-1])
+	}
+	ambiguous := false
+	for _, name := range item.Name {
+		name, _, _ := s.nlParser.ParseQuantity(name)
+		// the items which contain the name are chosen by the user
+		matched, exact := items.MatchName(name, s.nlParser.Normalize)
+		ambiguous = ambiguous || len(matched) > 1 || (len(matched) > 0 && !exact)
+		for _, m := range matched {
+			appendTarget(m)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil, xerrors.Errorf("item not found: %w", errItemNotFound)
+	}
+	if ambiguous {
+		return targets, nil, xerrors.Errorf("%d items found: %w", len(targets), errItemNotIdentified)
+	}
+
+	return s.deleteItems(ctx, conversationID, list.ID, targets)
+}
+
+func (s *Shopping) deleteItems(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, targets model.ShoppingItems) (model.ShoppingItems, *model.ShoppingSnapshot, error) {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID)
+	}
+	snapshot, err := s.shopping.DeleteItems(ctx, conversationID, listID, ids)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to delete shopping items: %w", err)
+	}
+
+	return targets, snapshot, nil
+}
+
+// updateFromItem renames the item specified by the index or the name,
+// it returns the labels of the item before and after the update.
+func (s *Shopping) updateFromItem(ctx context.Context, conversationID model.ConversationID, item *model.Item) (string, string, error) {
+	list, err := s.shopping.CurrentList(ctx, conversationID)
+	if err != nil {
+		return "", "", xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items, err := s.shopping.List(ctx, conversationID, list.ID)
+	if err != nil {
+		return "", "", xerrors.Errorf("failed to list shopping items: %w", err)
+	}
+
+	var targets model.ShoppingItems
+	if indexes := item.ResolveIndexes(len(items)); len(indexes) > 0 {
+		for _, idx := range indexes {
+			targets = append(targets, items[idx-1])
+		}
+	} else if len(item.Name) > 0 {
+		targets = s.matchNames(items, item.Name)
+	}
+	switch len(targets) {
+	case 0:
+		return "", "", xerrors.Errorf("item not found: %w", errItemNotFound)
+	case 1:
+	default:
+		return "", "", xerrors.Errorf("%d items found: %w", len(targets), errItemNotIdentified)
+	}
+
+	target := *targets[0]
+	before := target.Label()
+	newName := strings.TrimSpace(item.NewName)
+	name, quantity, unit := s.nlParser.ParseQuantity(newName)
+	target.Name = name
+	if name != newName {
+		// keep the quantity unless the new name has one
+		target.Quantity = quantity
+		target.Unit = unit
+	}
+	target.Category = s.nlParser.Category(name)
+	if err := s.shopping.UpdateItem(ctx, &target); err != nil {
+		return "", "", xerrors.Errorf("failed to update shopping item: %w", err)
 	}
 
-	ret := make([]*model.ShoppingItem, 0)
+	return before, target.Label(), nil
+}
 
-	indexes := item.UniqueIndexes()
-	if len(indexes) == 0 {
-		return ret, xerrors.Errorf("item not found: %w", errItemNotFound)
+// checkFromItem marks the items specified by indexes or names as purchased.
+func (s *Shopping) checkFromItem(ctx context.Context, conversationID model.ConversationID, item *model.Item) (model.ShoppingItems, error) {
+	list, err := s.shopping.CurrentList(ctx, conversationID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get current shopping list: %w", err)
+	}
+	items, err := s.shopping.List(ctx, conversationID, list.ID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list shopping items: %w", err)
 	}
 
-	ids := make([]string, 0, len(indexes))
-	for _, idx := range indexes {
-		if idx <= 0 || idx > len(items) {
-			continue
+	var targets model.ShoppingItems
+	if indexes := item.ResolveIndexes(len(items)); len(indexes) > 0 {
+		for _, idx := range indexes {
+			targets = append(targets, items[idx-1])
 		}
-		item := items[idx-1]
-		ret = append(ret, item)
-		ids = append(ids, item.ID)
+	} else if len(item.Name) > 0 {
+		// "牛乳" is the unchecked milk even if the checked one is on the list
+		targets = s.matchNames(items.Unchecked(), item.Name)
 	}
-	if len(ids) == 0 {
-		return ret, nil
+	targets = targets.Unchecked()
+	if len(targets) == 0 {
+		return nil, xerrors.Errorf("item not found: %w", errItemNotFound)
+	}
+
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID)
 	}
-	if err := s.shopping.DeleteItems(ctx, conversationID, ids); err != nil {
-		return nil, xerrors.Errorf("failed to delete shopping items: %w", err)
+	if err := s.shopping.CheckItems(ctx, conversationID, list.ID, ids); err != nil {
+		return nil, xerrors.Errorf("failed to check shopping items: %w", err)
 	}
 
-	return ret, nil
+	return targets, nil
+}
+
+// matchNames returns the items of the names, the items of the same name are preferred to the ones which contain it.
+func (s *Shopping) matchNames(items model.ShoppingItems, names []string) model.ShoppingItems {
+	targets := make(model.ShoppingItems, 0)
+	found := make(map[string]struct{})
+	for _, name := range names {
+		name, _, _ := s.nlParser.ParseQuantity(name)
+		matched, _ := items.MatchName(name, s.nlParser.Normalize)
+		for _, m := range matched {
+			if _, ok := found[m.ID]; ok {
+				continue
+			}
+			found[m.ID] = struct{}{}
+			targets = append(targets, m)
+		}
+	}
+	return targets
 }
 
 func (s *Shopping) HandleReminder(ctx context.Context, item *model.ReminderItem) error {
@@ -271,19 +811,49 @@ func (s *Shopping) HandleReminder(ctx context.Context, item *model.ReminderItem)
 		return nil
 	}
 
-	items, err := s.shopping.List(ctx, item.ConversationID)
+	// the payload is the id of the list to remind
+	lists, err := s.shopping.Lists(ctx, item.ConversationID)
+	if err != nil {
+		return xerrors.Errorf("failed to list shopping lists: %w", err)
+	}
+	list, err := lists.Get(model.ShoppingListID(item.Executor.Payload))
+	if err != nil {
+		// the list has been deleted
+		return nil
+	}
+
+	items, err := s.shopping.List(ctx, item.ConversationID, list.ID)
 	if err != nil {
 		return xerrors.Errorf("failed to list shopping items: %w", err)
 	}
+	// purchased items are not reminded
+	items = items.Unchecked()
 	if len(items) == 0 {
 		return nil
 	}
 
-	text := "【リマインド】\n今日の買い物リストはこちらです。\n" + items.Print(model.ListTypeDotted)
-	msg := s.message.ShoppingMenu(text, model.ShoppingReplyTypeAll)
+	lang, err := s.conversation.Language(ctx, item.ConversationID)
+	if err != nil {
+		return xerrors.Errorf("failed to get language: %w", err)
+	}
+	p := printer(lang)
+	text := p.Sprintf("【リマインド】\n今日の買い物リストはこちらです。\n%s", items.Print(model.ListTypeDotted, translator(p)))
+	if !list.IsDefault() {
+		text = p.Sprintf("【リマインド】\n今日の買い物リスト「%s」はこちらです。\n%s", list.Name, items.Print(model.ListTypeDotted, translator(p)))
+	}
+	set := s.message.Localize(lang)
+	msg := set.ReminderActions(set.ShoppingMenu(text, model.ShoppingReplyTypeAll), item.ID)
 	if err := s.bot.PushMessage(ctx, item.ConversationID, msg); err != nil {
 		return xerrors.Errorf("failed to reply message: %w", err)
 	}
 
 	return nil
 }
+
+// shoppingPrefix returns the message prefix which shows the name of a named list.
+func shoppingPrefix(p *message.Printer, list *model.ShoppingList) string {
+	if list.IsDefault() {
+		return p.Sprintf(prefixShopping)
+	}
+	return p.Sprintf("【買い物リスト: %s】", list.Name)
+}
@@ -0,0 +1,97 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/line/line-bot-sdk-go/v7/linebot"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/service"
+	"github.com/ww24/linebot/internal/config"
+	"github.com/ww24/linebot/mock/mock_repository"
+)
+
+func TestShopping_Handle_Intent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		status  model.ConversationStatusType
+		intent  *model.Intent
+		setup   func(*mock_repository.MockMessageProviderSet, *mock_repository.MockBot)
+		wantErr error
+	}{
+		{
+			name:   "delete all in neutral",
+			status: model.ConversationStatusTypeNeutral,
+			intent: &model.Intent{
+				Type: model.IntentTypeDelete,
+				Item: &model.Item{Action: model.ActionTypeDelete, All: true},
+			},
+			setup: func(m *mock_repository.MockMessageProviderSet, bot *mock_repository.MockBot) {
+				m.EXPECT().Localize(model.LanguageJapanese).Return(m)
+				m.EXPECT().ShoppingDeleteConfirmation("【買い物リスト】リストを空にしても良いですか？").Return(nil)
+				bot.EXPECT().ReplyMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: errResponseReturned,
+		},
+		{
+			name:   "update without a new name in neutral",
+			status: model.ConversationStatusTypeNeutral,
+			intent: &model.Intent{
+				Type: model.IntentTypeUpdate,
+				Item: &model.Item{Action: model.ActionTypeUpdate, Indexes: []int{2}},
+			},
+			setup: func(m *mock_repository.MockMessageProviderSet, bot *mock_repository.MockBot) {
+				m.EXPECT().Localize(model.LanguageJapanese).Return(m)
+				m.EXPECT().Text(gomock.Any()).Return(nil)
+				bot.EXPECT().ReplyMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: errResponseReturned,
+		},
+		{
+			name:   "a flow waits for a text",
+			status: model.ConversationStatusTypeReminderAddMessage,
+			intent: &model.Intent{
+				Type: model.IntentTypeDelete,
+				Item: &model.Item{Action: model.ActionTypeDelete, All: true},
+			},
+			setup:   func(*mock_repository.MockMessageProviderSet, *mock_repository.MockBot) {},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			conversation := mock_repository.NewMockConversation(ctrl)
+			message := mock_repository.NewMockMessageProviderSet(ctrl)
+			bot := mock_repository.NewMockBot(ctrl)
+			tt.setup(message, bot)
+
+			s := NewShopping(
+				service.NewConversation(conversation, &config.Time{}),
+				service.NewShopping(conversation, mock_repository.NewMockShopping(ctrl), &config.Shopping{}),
+				mock_repository.NewMockNLParser(ctrl),
+				message,
+				service.NewBot(bot, message),
+			)
+			e := &model.Event{
+				Event: &linebot.Event{
+					Type:    linebot.EventTypeMessage,
+					Source:  &linebot.EventSource{Type: linebot.EventSourceTypeUser, UserID: "user"},
+					Message: linebot.NewTextMessage("text"),
+				},
+				Intent:   tt.intent,
+				Language: model.LanguageJapanese,
+			}
+			e.SetStatus(tt.status)
+			err := s.Handle(ctx, e)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
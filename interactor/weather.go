@@ -9,34 +9,38 @@ import (
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
 	"github.com/ww24/linebot/domain/service"
+	"github.com/ww24/linebot/internal/code"
+	"github.com/ww24/linebot/log"
 )
 
 const (
-	triggerWeather = "天気"
-	urlPathPrefix  = "/image"
+	urlPathPrefix = "/image"
 )
 
 type Weather struct {
-	weather service.Weather
-	message repository.MessageProviderSet
-	bot     service.Bot
+	conversation service.Conversation
+	weather      service.Weather
+	message      repository.MessageProviderSet
+	bot          service.Bot
 }
 
 func NewWeather(
+	conversation service.Conversation,
 	weather service.Weather,
 	message repository.MessageProviderSet,
 	bot service.Bot,
 ) *Weather {
 	return &Weather{
-		weather: weather,
-		message: message,
-		bot:     bot,
+		conversation: conversation,
+		weather:      weather,
+		message:      message,
+		bot:          bot,
 	}
 }
 
 func (w *Weather) Handle(ctx context.Context, e *model.Event) error {
 	err := e.HandleTypeMessage(ctx, func(context.Context, *model.Event) error {
-		if e.FilterText(triggerWeather) {
+		if e.IntentType() == model.IntentTypeWeather {
 			return w.handleWeather(ctx, e)
 		}
 
@@ -50,7 +54,7 @@ func (w *Weather) Handle(ctx context.Context, e *model.Event) error {
 }
 
 func (w *Weather) handleWeather(ctx context.Context, e *model.Event) error {
-	imageURL, err := w.weather.LatestImage(ctx)
+	imageURL, err := w.weather.LatestImage(ctx, e.Location)
 	if err != nil {
 		return xerrors.Errorf("weather.Fetch: %w", err)
 	}
@@ -64,3 +68,37 @@ func (w *Weather) handleWeather(ctx context.Context, e *model.Event) error {
 
 	return errResponseReturned
 }
+
+func (w *Weather) HandleReminder(ctx context.Context, item *model.ReminderItem) error {
+	if item.Executor.Type != model.ExecutorTypeWeather {
+		return nil
+	}
+
+	loc, err := w.conversation.Location(ctx, item.ConversationID)
+	if err != nil {
+		return xerrors.Errorf("conversation.Location: %w", err)
+	}
+
+	imageURL, err := w.weather.LatestImage(ctx, loc)
+	if err != nil {
+		if code.From(err) == code.NotFound {
+			slog.WarnContext(ctx, "interactor: weather image not found", log.Err(err))
+			return nil
+		}
+		return xerrors.Errorf("weather.LatestImage: %w", err)
+	}
+
+	slog.InfoContext(ctx, "interactor: push image message", slog.String("imageURL", imageURL))
+
+	lang, err := w.conversation.Language(ctx, item.ConversationID)
+	if err != nil {
+		return xerrors.Errorf("conversation.Language: %w", err)
+	}
+	set := w.message.Localize(lang)
+	msg := set.ReminderActions(set.Image(imageURL, imageURL), item.ID)
+	if err := w.bot.PushMessage(ctx, item.ConversationID, msg); err != nil {
+		return xerrors.Errorf("bot.PushMessage: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ const (
 	OK Code = iota
 	Unexpected
 	NotFound
+	AlreadyExists
 )
 
 type internalError struct {
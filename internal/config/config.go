@@ -12,4 +12,7 @@ var Set = wire.NewSet(
 	NewAccessLog,
 	NewServiceEndpoint,
 	NewSentry,
+	NewScheduler,
+	NewNL,
+	NewShopping,
 )
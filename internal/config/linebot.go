@@ -14,8 +14,8 @@ type LINEBot struct {
 	LINEChannelAccessToken     string   `split_words:"true" required:"true"`
 	AllowConvIDs               []string `split_words:"true"`
 	Port                       int      `split_words:"true" default:"8000"`
-	CloudTasksLocation         string   `split_words:"true" required:"true"`
-	CloudTasksQueue            string   `split_words:"true" required:"true"`
+	CloudTasksLocation         string   `split_words:"true"`
+	CloudTasksQueue            string   `split_words:"true"`
 	InvokerServiceAccountID    string   `split_words:"true" required:"true"`
 	InvokerServiceAccountEmail string   `split_words:"true" required:"true"`
 }
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/xerrors"
+)
+
+type NL struct {
+	// CategoryDictionary is the path of a CSV file of "base form,category" lines,
+	// the entries extend and override the embedded category dictionary.
+	CategoryDictionary string `split_words:"true"`
+	// IntentRules is the path of a CSV file of "intent,pattern" lines,
+	// the rules are tried before the embedded intent rules.
+	IntentRules string `split_words:"true"`
+	// UserDictionary is the path of a user dictionary of kagome,
+	// the words extend and override the embedded user dictionary.
+	UserDictionary string `split_words:"true"`
+}
+
+func NewNL() (*NL, error) {
+	var conf NL
+	if err := envconfig.Process("NL", &conf); err != nil {
+		return nil, xerrors.Errorf("failed to parse nl config: %w", err)
+	}
+	return &conf, nil
+}
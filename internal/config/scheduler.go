@@ -0,0 +1,36 @@
+package config
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/xerrors"
+)
+
+const (
+	SchedulerBackendCloudTasks = "cloudtasks"
+	SchedulerBackendLocal      = "local"
+)
+
+type Scheduler struct {
+	// Backend is "cloudtasks" or "local".
+	Backend string `split_words:"true" default:"cloudtasks"`
+	// LocalStateFile is the file which persists pending tasks of the local backend.
+	LocalStateFile string `split_words:"true" default:"scheduler.json"`
+	// LocalSyncInterval is the interval of the local backend to handle the schedule
+	// as the Cloud Scheduler does for the cloudtasks backend.
+	LocalSyncInterval time.Duration `split_words:"true" default:"1h"`
+}
+
+func NewScheduler() (*Scheduler, error) {
+	var conf Scheduler
+	if err := envconfig.Process("SCHEDULER", &conf); err != nil {
+		return nil, xerrors.Errorf("failed to parse scheduler config: %w", err)
+	}
+	switch conf.Backend {
+	case SchedulerBackendCloudTasks, SchedulerBackendLocal:
+	default:
+		return nil, xerrors.Errorf("unknown scheduler backend: %q", conf.Backend)
+	}
+	return &conf, nil
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/xerrors"
+)
+
+type Shopping struct {
+	// AisleOrder is the comma separated categories in the order of the aisles of a store,
+	// e.g. "野菜・果物,肉・魚,日用品". The default order is used if it is empty.
+	AisleOrder []string `split_words:"true"`
+}
+
+func NewShopping() (*Shopping, error) {
+	var conf Shopping
+	if err := envconfig.Process("SHOPPING", &conf); err != nil {
+		return nil, xerrors.Errorf("failed to parse shopping config: %w", err)
+	}
+	return &conf, nil
+}
@@ -0,0 +1,149 @@
+package i18n
+
+// english maps the Japanese texts to the English texts.
+//
+//nolint:gochecknoglobals
+var english = map[string]string{
+	// common
+	"予期せぬエラーが発生しました": "An unexpected error occurred.",
+	"キャンセルしました。":     "Canceled.",
+	"、":              ", ",
+	"・":              ", ",
+	"（%s）":           " (%s)",
+	"「%s」":           " \"%s\"",
+
+	// help
+	"【ヘルプ】\n次のように話しかけて下さい。\n\n■買い物リスト\n・買い物リスト\n・牛乳と卵を買い物リストに追加\n・1番を削除、牛乳を削除\n・2番買った\n・2番をバター無塩に変更\n・いつもの\n\n■リマインダー\n・リマインダー\n・明日の8時に買い物リストをリマインド\n\n■天気\n・今日の天気は？\n\n■設定\n・タイムゾーン Asia/Tokyo\n・単語登録 オイコス おいこす\n・言語 English": "[Help]\nTalk to me like this.\n\n■Shopping list\n- shopping list\n- add milk and eggs to the list\n- delete 1, delete milk\n- bought 2\n- rename 2 to unsalted butter\n- usual\n\n■Reminder\n- reminder\n- remind me\n\n■Weather\n- weather\n\n■Settings\n- timezone Asia/Tokyo\n- language 日本語",
+
+	// labels
+	"追加":      "Add",
+	"いつもの":    "Usual",
+	"リスト切替":   "Lists",
+	"削除":      "Delete",
+	"購入済みを削除": "Clear bought",
+	"表示":      "Show",
+	"元に戻す":    "Undo",
+	"新規作成":    "New list",
+	"全部追加":    "Add all",
+	"全部削除":    "Delete all",
+	"キャンセル":   "Cancel",
+	"1回だけ":    "Once",
+	"毎日":      "Daily",
+	"取り消す":    "Undo",
+	"10分後":    "In 10 min",
+	"1時間後":    "In 1 hour",
+	"完了":      "Done",
+	"時刻設定":    "Set time",
+	"日時設定":    "Set date",
+	"「%s」を削除": "Delete \"%s\"",
+
+	// shopping
+	"【買い物リスト】":                          "[Shopping list]",
+	"【買い物リスト: %s】":                      "[Shopping list: %s]",
+	"リストは空です。\n何をしますか？":                 "The list is empty.\nWhat would you like to do?",
+	"次の商品を追加しました。\n%s":                  "Added the following items.\n%s",
+	"次の商品を削除しました。\n%s":                  "Deleted the following items.\n%s",
+	"次の商品を購入済みにしました。\n%s":               "Marked the following items as bought.\n%s",
+	"次の商品を元に戻しました。\n%s":                 "Restored the following items.\n%s",
+	"次の商品が見つかりました。\n削除する商品を選んで下さい。\n%s": "Found the following items.\nChoose the item to delete.\n%s",
+	"%d件登録されています。\n%s\n\n何をしますか？":       "%d items.\n%s\n\nWhat would you like to do?",
+	"%d件追加されました。\n%s":                   "%d items were added.\n%s",
+	"購入済みの商品を%d件削除しました。":                "Deleted %d bought items.",
+	"追加する商品がありませんでした。":                  "There were no items to add.",
+	"削除する商品が見つかりませんでした。":                "No items to delete were found.",
+	"削除する商品が見つかりませんでした。\n削除する場合は「○番を削除」や「牛乳を削除」と入力してみて下さい。": "No items to delete were found.\nTry \"delete 2\" or \"delete milk\".",
+	"購入済みにする商品が見つかりませんでした。\n「○番買った」と入力してみて下さい。":             "No items to mark as bought were found.\nTry \"bought 2\".",
+	"変更する商品が見つかりませんでした。\n「2番をバター無塩に変更」のように入力してみて下さい。":       "No item to rename was found.\nTry \"rename 2 to unsalted butter\".",
+	"変更後の名前が見つかりませんでした。\n「2番をバター無塩に変更」のように入力してみて下さい。":       "No new name was found.\nTry \"rename 2 to unsalted butter\".",
+	"変更する商品が複数見つかりました。\n商品は1つずつ番号で指定して下さい。":                 "Found several items to rename.\nSpecify one item by its number.",
+	"「%s」を「%s」に変更しました。":                   "Renamed \"%s\" to \"%s\".",
+	"元に戻せませんでした。\n削除してから%d分以内に操作してください。":  "Could not undo.\nUndo within %d minutes after deleting.",
+	"いつもの商品はこちらです。\nタップするとリストに追加します。\n%s": "Here are your usual items.\nTap one to add it to the list.\n%s",
+	"いつもの商品はまだありません。\n何度か買った商品をおすすめします。":  "There are no usual items yet.\nItems you buy several times will be suggested.",
+	"「%s」に切り替えました。":                       "Switched to \"%s\".",
+	"「%s」を作成しました。":                        "Created \"%s\".",
+	"「%s」を削除しました。":                        "Deleted \"%s\".",
+	"【買い物リスト】リストを空にしても良いですか？":             "[Shopping list] Are you sure you want to empty the list?",
+	"【買い物リスト】追加する商品を1行に1つずつ入力してください。":     "[Shopping list] Enter the items to add, one per line.",
+	"【買い物リスト】どのリストを使いますか？":                "[Shopping list] Which list do you want to use?",
+	"【買い物リスト】新しいリストの名前を入力してください。":         "[Shopping list] Enter the name of the new list.",
+	"【買い物リスト】リストが見つかりませんでした。":             "[Shopping list] The list was not found.",
+	"【買い物リスト】「%s」というリストは既にあります。":          "[Shopping list] The list \"%s\" already exists.",
+	"【買い物リスト】「%s」というリストは見つかりませんでした。":      "[Shopping list] The list \"%s\" was not found.",
+	"【買い物リスト】「%s」と登録されている商品を削除しても良いですか？":  "[Shopping list] Are you sure you want to delete the list \"%s\" and its items?",
+	"【買い物リスト】「%s」の名前は変更できません。":            "[Shopping list] The list \"%s\" cannot be renamed.",
+	"購入済み":   "Bought",
+	"（そろそろ）": " (soon)",
+	"野菜・果物":  "Fruits & vegetables",
+	"肉・魚":    "Meat & fish",
+	"乳製品・卵":  "Dairy & eggs",
+	"パン・米・麺": "Bread, rice & noodles",
+	"調味料":    "Seasonings",
+	"冷凍食品":   "Frozen foods",
+	"お菓子":    "Snacks",
+	"飲料":     "Drinks",
+	"日用品":    "Household goods",
+	"その他":    "Other",
+
+	// reminder
+	"買い物リスト":   "shopping list",
+	"メッセージ":    "message",
+	"天気":       "weather",
+	"%d時間後":    "in %d hours",
+	"%d分後":     "in %d minutes",
+	"営業日のみ":    "business days only",
+	"祝日を除く":    "except holidays",
+	"%s〜%sを除く": "except %s-%s",
+	"毎日%s":     "daily at %s",
+	"毎週%s曜%s":  "every %s at %s",
+	"毎月%d日%s":  "monthly on day %d at %s",
+	"日":        "Sun",
+	"月":        "Mon",
+	"火":        "Tue",
+	"水":        "Wed",
+	"木":        "Thu",
+	"金":        "Fri",
+	"土":        "Sat",
+	"（一時停止中）":  " (paused)",
+	"リマインダーを削除しますか？":                                     "Delete the reminder?",
+	"【リマインド】\n%s":                                        "[Reminder]\n%s",
+	"【リマインド】\n今日の買い物リストはこちらです。\n%s":                      "[Reminder]\nHere is today's shopping list.\n%s",
+	"【リマインド】\n今日の買い物リスト「%s」はこちらです。\n%s":                  "[Reminder]\nHere is today's shopping list \"%s\".\n%s",
+	"【リマインダー】%d件登録されています。\n%s\n\n何をしますか？":                "[Reminder] %d reminders are registered.\n%s\n\nWhat would you like to do?",
+	"【リマインダー】登録されていません。\n何をしますか？":                        "[Reminder] No reminders are registered.\nWhat would you like to do?",
+	"【リマインダー】%sに%sをリマインドします。":                            "[Reminder] I will remind you of %[2]s: %[1]s.",
+	"【リマインダー】%sにもう一度リマインドします。":                           "[Reminder] I will remind you again %s.",
+	"【リマインダー】%sに変更しました。":                                 "[Reminder] Changed to %s.",
+	"【リマインダー】%sのリマインドを一時停止しました。":                         "[Reminder] Paused the reminder of %s.",
+	"【リマインダー】%sのリマインドを再開しました。":                           "[Reminder] Resumed the reminder of %s.",
+	"【リマインダー】%sをリマインドします。\n1回だけリマインドしますか？毎日リマインドしますか？":   "[Reminder] I will remind you of %s.\nOnce or daily?",
+	"【リマインダー】「%s」をリマインドします。\n1回だけリマインドしますか？毎日リマインドしますか？": "[Reminder] I will remind you of \"%s\".\nOnce or daily?",
+	"【リマインダー】cronで設定されたリマインダーは時刻を変更できません。":               "[Reminder] The time of a reminder set by cron cannot be changed.",
+	"【リマインダー】いつに変更しますか？":                                 "[Reminder] When do you want to change it to?",
+	"【リマインダー】何時に変更しますか？":                                 "[Reminder] What time do you want to change it to?",
+	"【リマインダー】いつリマインドしますか？":                               "[Reminder] When should I remind you?",
+	"【リマインダー】毎日何時にリマインドしますか？":                            "[Reminder] What time should I remind you every day?",
+	"【リマインダー】新規追加します。\n何をリマインドしますか？":                     "[Reminder] Adding a new reminder.\nWhat should I remind you of?",
+	"【リマインダー】リマインドするメッセージを入力してください。":                     "[Reminder] Enter the message to remind you of.",
+	"【リマインダー】メッセージが見つかりませんでした。\nもう一度最初からやり直してください。":      "[Reminder] The message was not found.\nPlease start over.",
+	"【リマインダー】リマインダーが見つかりませんでした。":                         "[Reminder] The reminder was not found.",
+	"【リマインダー】リマインダーを削除しました。":                             "[Reminder] Deleted the reminder.",
+	"【リマインダー】完了しました。":                                    "[Reminder] Done.",
+	"【リマインダー】過去の日時は指定できません。":                             "[Reminder] You cannot choose a past date.",
+	"【リマインダー】過去の日時は指定できません。\n未来の日時を選択してください。":            "[Reminder] You cannot choose a past date.\nChoose a future date.",
+	"【リマインダー】何をリマインドするか見つかりませんでした。\n「明日の8時に買い物リストをリマインド」のように入力してみて下さい。": "[Reminder] What to remind you of was not found.\nTap \"Add\" to set a reminder.",
+	"【リマインダー】日時が見つかりませんでした。\n「明日の8時に買い物リストをリマインド」のように入力してみて下さい。":        "[Reminder] The date was not found.\nTap \"Add\" to set a reminder.",
+
+	// setting
+	"登録する場合は「単語登録 オイコス おいこす」のように単語と読みを入力してください。":                      "To add a word, enter the word and its reading like \"単語登録 オイコス おいこす\".",
+	"【設定】タイムゾーンは%sです。\n変更する場合は「タイムゾーン America/New_York」のように入力してください。": "[Settings] The time zone is %s.\nTo change it, enter \"timezone America/New_York\".",
+	"【設定】%sは不明なタイムゾーンです。\n「Asia/Tokyo」のように入力してください。":                  "[Settings] %s is an unknown time zone.\nEnter a time zone such as \"Asia/Tokyo\".",
+	"【設定】タイムゾーンを%sに変更しました。":                                           "[Settings] Changed the time zone to %s.",
+	"【設定】言語は%sです。\n変更する場合は「言語 English」のように入力してください。":                  "[Settings] The language is %s.\nTo change it, enter \"language 日本語\".",
+	"【設定】%sは不明な言語です。\n「日本語」か「English」を入力してください。":                      "[Settings] %s is an unknown language.\nEnter \"English\" or \"日本語\".",
+	"【設定】言語を%sに変更しました。":                                               "[Settings] Changed the language to %s.",
+	"【設定】登録された単語はありません。\n%s":                                          "[Settings] There are no words.\n%s",
+	"【設定】登録された単語は次の通りです。\n%s\n\n%s":                                   "[Settings] The words are as follows.\n%s\n\n%s",
+	"【設定】単語を登録できませんでした。\n読みはひらがなかカタカナで入力してください。\n%s":                  "[Settings] Could not add the word.\nEnter the reading in hiragana or katakana.\n%s",
+	"【設定】「%s（%s）」を登録しました。":                                            "[Settings] Added \"%s (%s)\".",
+}
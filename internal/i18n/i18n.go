@@ -0,0 +1,27 @@
+// Package i18n provides the message catalogue of the texts to users.
+// The Japanese texts are the keys of the catalogue and the translations are registered for the other languages.
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+//nolint:gochecknoglobals
+var messages = newCatalog()
+
+func newCatalog() catalog.Catalog {
+	b := catalog.NewBuilder(catalog.Fallback(language.Japanese))
+	for key, msg := range english {
+		if err := b.SetString(language.English, key, msg); err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+// Printer returns the printer of the language, the texts without the translation are printed as they are.
+func Printer(tag language.Tag) *message.Printer {
+	return message.NewPrinter(tag, message.Catalog(messages))
+}
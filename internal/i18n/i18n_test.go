@@ -0,0 +1,29 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+var verbPattern = regexp.MustCompile(`%(?:\[\d+\])?[sdv]`) //nolint:gochecknoglobals
+
+func TestEnglish_Verbs(t *testing.T) {
+	t.Parallel()
+	for key, msg := range english {
+		assert.Len(t, verbPattern.FindAllString(msg, -1), len(verbPattern.FindAllString(key, -1)), key)
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	t.Parallel()
+	const key = "【リマインダー】%sに%sをリマインドします。"
+	assert.Equal(t, "【リマインダー】毎日08:00に買い物リストをリマインドします。",
+		Printer(language.Japanese).Sprintf(key, "毎日08:00", "買い物リスト"))
+	assert.Equal(t, "[Reminder] I will remind you of shopping list: daily at 08:00.",
+		Printer(language.English).Sprintf(key, "daily at 08:00", "shopping list"))
+	// the texts without the translation are printed as they are
+	assert.Equal(t, "YES", Printer(language.English).Sprintf("YES"))
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	linebot "github.com/line/line-bot-sdk-go/v7/linebot"
 	model "github.com/ww24/linebot/domain/model"
@@ -146,6 +147,20 @@ func (m *MockMessageProviderSet) EXPECT() *MockMessageProviderSetMockRecorder {
 	return m.recorder
 }
 
+// DateTimePicker mocks base method.
+func (m *MockMessageProviderSet) DateTimePicker(text, data string, minTime time.Time) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DateTimePicker", text, data, minTime)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// DateTimePicker indicates an expected call of DateTimePicker.
+func (mr *MockMessageProviderSetMockRecorder) DateTimePicker(text, data, minTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DateTimePicker", reflect.TypeOf((*MockMessageProviderSet)(nil).DateTimePicker), text, data, minTime)
+}
+
 // Image mocks base method.
 func (m *MockMessageProviderSet) Image(originalURL, previewURL string) repository.MessageProvider {
 	m.ctrl.T.Helper()
@@ -160,6 +175,62 @@ func (mr *MockMessageProviderSetMockRecorder) Image(originalURL, previewURL any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Image", reflect.TypeOf((*MockMessageProviderSet)(nil).Image), originalURL, previewURL)
 }
 
+// Localize mocks base method.
+func (m *MockMessageProviderSet) Localize(arg0 model.Language) repository.MessageProviderSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Localize", arg0)
+	ret0, _ := ret[0].(repository.MessageProviderSet)
+	return ret0
+}
+
+// Localize indicates an expected call of Localize.
+func (mr *MockMessageProviderSetMockRecorder) Localize(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localize", reflect.TypeOf((*MockMessageProviderSet)(nil).Localize), arg0)
+}
+
+// Prompt mocks base method.
+func (m *MockMessageProviderSet) Prompt(arg0 string) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prompt", arg0)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// Prompt indicates an expected call of Prompt.
+func (mr *MockMessageProviderSetMockRecorder) Prompt(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prompt", reflect.TypeOf((*MockMessageProviderSet)(nil).Prompt), arg0)
+}
+
+// ReminderActions mocks base method.
+func (m *MockMessageProviderSet) ReminderActions(arg0 repository.MessageProvider, arg1 model.ReminderItemID) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReminderActions", arg0, arg1)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ReminderActions indicates an expected call of ReminderActions.
+func (mr *MockMessageProviderSetMockRecorder) ReminderActions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReminderActions", reflect.TypeOf((*MockMessageProviderSet)(nil).ReminderActions), arg0, arg1)
+}
+
+// ReminderAdded mocks base method.
+func (m *MockMessageProviderSet) ReminderAdded(text, undoData string) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReminderAdded", text, undoData)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ReminderAdded indicates an expected call of ReminderAdded.
+func (mr *MockMessageProviderSetMockRecorder) ReminderAdded(text, undoData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReminderAdded", reflect.TypeOf((*MockMessageProviderSet)(nil).ReminderAdded), text, undoData)
+}
+
 // ReminderChoices mocks base method.
 func (m *MockMessageProviderSet) ReminderChoices(arg0 string, arg1 []string, arg2 []model.ExecutorType) repository.MessageProvider {
 	m.ctrl.T.Helper()
@@ -189,17 +260,45 @@ func (mr *MockMessageProviderSetMockRecorder) ReminderDeleteConfirmation(text, d
 }
 
 // ReminderMenu mocks base method.
-func (m *MockMessageProviderSet) ReminderMenu(arg0 string, arg1 model.ReminderReplyType, arg2 []*model.ReminderItem) repository.MessageProvider {
+func (m *MockMessageProviderSet) ReminderMenu(arg0 string, arg1 model.ReminderReplyType, arg2 []*model.ReminderItem, arg3 *time.Location) repository.MessageProvider {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReminderMenu", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ReminderMenu", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(repository.MessageProvider)
 	return ret0
 }
 
 // ReminderMenu indicates an expected call of ReminderMenu.
-func (mr *MockMessageProviderSetMockRecorder) ReminderMenu(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockMessageProviderSetMockRecorder) ReminderMenu(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReminderMenu", reflect.TypeOf((*MockMessageProviderSet)(nil).ReminderMenu), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReminderMenu", reflect.TypeOf((*MockMessageProviderSet)(nil).ReminderMenu), arg0, arg1, arg2, arg3)
+}
+
+// ReminderScheduleChoices mocks base method.
+func (m *MockMessageProviderSet) ReminderScheduleChoices(text string, executorType model.ExecutorType) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReminderScheduleChoices", text, executorType)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ReminderScheduleChoices indicates an expected call of ReminderScheduleChoices.
+func (mr *MockMessageProviderSetMockRecorder) ReminderScheduleChoices(text, executorType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReminderScheduleChoices", reflect.TypeOf((*MockMessageProviderSet)(nil).ReminderScheduleChoices), text, executorType)
+}
+
+// ShoppingDeleteChoices mocks base method.
+func (m *MockMessageProviderSet) ShoppingDeleteChoices(text string, items model.ShoppingItems) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingDeleteChoices", text, items)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ShoppingDeleteChoices indicates an expected call of ShoppingDeleteChoices.
+func (mr *MockMessageProviderSetMockRecorder) ShoppingDeleteChoices(text, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingDeleteChoices", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingDeleteChoices), text, items)
 }
 
 // ShoppingDeleteConfirmation mocks base method.
@@ -216,6 +315,34 @@ func (mr *MockMessageProviderSetMockRecorder) ShoppingDeleteConfirmation(arg0 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingDeleteConfirmation", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingDeleteConfirmation), arg0)
 }
 
+// ShoppingListDeleteConfirmation mocks base method.
+func (m *MockMessageProviderSet) ShoppingListDeleteConfirmation(text string, listID model.ShoppingListID) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingListDeleteConfirmation", text, listID)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ShoppingListDeleteConfirmation indicates an expected call of ShoppingListDeleteConfirmation.
+func (mr *MockMessageProviderSetMockRecorder) ShoppingListDeleteConfirmation(text, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingListDeleteConfirmation", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingListDeleteConfirmation), text, listID)
+}
+
+// ShoppingLists mocks base method.
+func (m *MockMessageProviderSet) ShoppingLists(text string, lists model.ShoppingLists, current model.ShoppingListID) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingLists", text, lists, current)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ShoppingLists indicates an expected call of ShoppingLists.
+func (mr *MockMessageProviderSetMockRecorder) ShoppingLists(text, lists, current any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingLists", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingLists), text, lists, current)
+}
+
 // ShoppingMenu mocks base method.
 func (m *MockMessageProviderSet) ShoppingMenu(arg0 string, arg1 model.ShoppingReplyType) repository.MessageProvider {
 	m.ctrl.T.Helper()
@@ -230,6 +357,34 @@ func (mr *MockMessageProviderSetMockRecorder) ShoppingMenu(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingMenu", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingMenu), arg0, arg1)
 }
 
+// ShoppingSuggestions mocks base method.
+func (m *MockMessageProviderSet) ShoppingSuggestions(text string, suggestions model.Suggestions) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingSuggestions", text, suggestions)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ShoppingSuggestions indicates an expected call of ShoppingSuggestions.
+func (mr *MockMessageProviderSetMockRecorder) ShoppingSuggestions(text, suggestions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingSuggestions", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingSuggestions), text, suggestions)
+}
+
+// ShoppingUndo mocks base method.
+func (m *MockMessageProviderSet) ShoppingUndo(text string, rt model.ShoppingReplyType, snapshotID model.ShoppingSnapshotID) repository.MessageProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingUndo", text, rt, snapshotID)
+	ret0, _ := ret[0].(repository.MessageProvider)
+	return ret0
+}
+
+// ShoppingUndo indicates an expected call of ShoppingUndo.
+func (mr *MockMessageProviderSetMockRecorder) ShoppingUndo(text, rt, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingUndo", reflect.TypeOf((*MockMessageProviderSet)(nil).ShoppingUndo), text, rt, snapshotID)
+}
+
 // Text mocks base method.
 func (m *MockMessageProviderSet) Text(arg0 string) repository.MessageProvider {
 	m.ctrl.T.Helper()
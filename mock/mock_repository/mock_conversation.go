@@ -40,6 +40,21 @@ func (m *MockConversation) EXPECT() *MockConversationMockRecorder {
 	return m.recorder
 }
 
+// GetSetting mocks base method.
+func (m *MockConversation) GetSetting(arg0 context.Context, arg1 model.ConversationID) (*model.ConversationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetting", arg0, arg1)
+	ret0, _ := ret[0].(*model.ConversationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetting indicates an expected call of GetSetting.
+func (mr *MockConversationMockRecorder) GetSetting(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockConversation)(nil).GetSetting), arg0, arg1)
+}
+
 // GetStatus mocks base method.
 func (m *MockConversation) GetStatus(arg0 context.Context, arg1 model.ConversationID) (*model.ConversationStatus, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +70,20 @@ func (mr *MockConversationMockRecorder) GetStatus(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockConversation)(nil).GetStatus), arg0, arg1)
 }
 
+// SetSetting mocks base method.
+func (m *MockConversation) SetSetting(arg0 context.Context, arg1 *model.ConversationSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSetting", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSetting indicates an expected call of SetSetting.
+func (mr *MockConversationMockRecorder) SetSetting(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSetting", reflect.TypeOf((*MockConversation)(nil).SetSetting), arg0, arg1)
+}
+
 // SetStatus mocks base method.
 func (m *MockConversation) SetStatus(arg0 context.Context, arg1 *model.ConversationStatus) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dictionary.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dictionary.go -destination=../../mock/mock_repository/mock_dictionary.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/ww24/linebot/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDictionary is a mock of Dictionary interface.
+type MockDictionary struct {
+	ctrl     *gomock.Controller
+	recorder *MockDictionaryMockRecorder
+}
+
+// MockDictionaryMockRecorder is the mock recorder for MockDictionary.
+type MockDictionaryMockRecorder struct {
+	mock *MockDictionary
+}
+
+// NewMockDictionary creates a new mock instance.
+func NewMockDictionary(ctrl *gomock.Controller) *MockDictionary {
+	mock := &MockDictionary{ctrl: ctrl}
+	mock.recorder = &MockDictionaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDictionary) EXPECT() *MockDictionaryMockRecorder {
+	return m.recorder
+}
+
+// AddWord mocks base method.
+func (m *MockDictionary) AddWord(arg0 context.Context, arg1 *model.UserWord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWord indicates an expected call of AddWord.
+func (mr *MockDictionaryMockRecorder) AddWord(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWord", reflect.TypeOf((*MockDictionary)(nil).AddWord), arg0, arg1)
+}
+
+// FindWords mocks base method.
+func (m *MockDictionary) FindWords(arg0 context.Context, arg1 model.ConversationID) (model.UserWords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWords", arg0, arg1)
+	ret0, _ := ret[0].(model.UserWords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWords indicates an expected call of FindWords.
+func (mr *MockDictionaryMockRecorder) FindWords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWords", reflect.TypeOf((*MockDictionary)(nil).FindWords), arg0, arg1)
+}
@@ -11,8 +11,10 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/ww24/linebot/domain/model"
+	repository "github.com/ww24/linebot/domain/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +41,34 @@ func (m *MockNLParser) EXPECT() *MockNLParserMockRecorder {
 	return m.recorder
 }
 
+// Category mocks base method.
+func (m *MockNLParser) Category(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Category", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Category indicates an expected call of Category.
+func (mr *MockNLParserMockRecorder) Category(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Category", reflect.TypeOf((*MockNLParser)(nil).Category), arg0)
+}
+
+// Normalize mocks base method.
+func (m *MockNLParser) Normalize(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockNLParserMockRecorder) Normalize(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockNLParser)(nil).Normalize), arg0)
+}
+
 // Parse mocks base method.
 func (m *MockNLParser) Parse(arg0 string) *model.Item {
 	m.ctrl.T.Helper()
@@ -52,3 +82,125 @@ func (mr *MockNLParserMockRecorder) Parse(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockNLParser)(nil).Parse), arg0)
 }
+
+// ParseQuantity mocks base method.
+func (m *MockNLParser) ParseQuantity(arg0 string) (string, int, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseQuantity", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(string)
+	return ret0, ret1, ret2
+}
+
+// ParseQuantity indicates an expected call of ParseQuantity.
+func (mr *MockNLParserMockRecorder) ParseQuantity(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseQuantity", reflect.TypeOf((*MockNLParser)(nil).ParseQuantity), arg0)
+}
+
+// MockIntentClassifier is a mock of IntentClassifier interface.
+type MockIntentClassifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntentClassifierMockRecorder
+}
+
+// MockIntentClassifierMockRecorder is the mock recorder for MockIntentClassifier.
+type MockIntentClassifierMockRecorder struct {
+	mock *MockIntentClassifier
+}
+
+// NewMockIntentClassifier creates a new mock instance.
+func NewMockIntentClassifier(ctrl *gomock.Controller) *MockIntentClassifier {
+	mock := &MockIntentClassifier{ctrl: ctrl}
+	mock.recorder = &MockIntentClassifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntentClassifier) EXPECT() *MockIntentClassifierMockRecorder {
+	return m.recorder
+}
+
+// Classify mocks base method.
+func (m *MockIntentClassifier) Classify(arg0 model.Language, arg1 string) *model.Intent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Classify", arg0, arg1)
+	ret0, _ := ret[0].(*model.Intent)
+	return ret0
+}
+
+// Classify indicates an expected call of Classify.
+func (mr *MockIntentClassifierMockRecorder) Classify(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Classify", reflect.TypeOf((*MockIntentClassifier)(nil).Classify), arg0, arg1)
+}
+
+// WithWords mocks base method.
+func (m *MockIntentClassifier) WithWords(arg0 model.UserWords) (repository.IntentClassifier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithWords", arg0)
+	ret0, _ := ret[0].(repository.IntentClassifier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithWords indicates an expected call of WithWords.
+func (mr *MockIntentClassifierMockRecorder) WithWords(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithWords", reflect.TypeOf((*MockIntentClassifier)(nil).WithWords), arg0)
+}
+
+// MockScheduleParser is a mock of ScheduleParser interface.
+type MockScheduleParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleParserMockRecorder
+}
+
+// MockScheduleParserMockRecorder is the mock recorder for MockScheduleParser.
+type MockScheduleParserMockRecorder struct {
+	mock *MockScheduleParser
+}
+
+// NewMockScheduleParser creates a new mock instance.
+func NewMockScheduleParser(ctrl *gomock.Controller) *MockScheduleParser {
+	mock := &MockScheduleParser{ctrl: ctrl}
+	mock.recorder = &MockScheduleParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleParser) EXPECT() *MockScheduleParserMockRecorder {
+	return m.recorder
+}
+
+// ParseExclusion mocks base method.
+func (m *MockScheduleParser) ParseExclusion(arg0 string, arg1 time.Time) (*model.Exclusion, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseExclusion", arg0, arg1)
+	ret0, _ := ret[0].(*model.Exclusion)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// ParseExclusion indicates an expected call of ParseExclusion.
+func (mr *MockScheduleParserMockRecorder) ParseExclusion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseExclusion", reflect.TypeOf((*MockScheduleParser)(nil).ParseExclusion), arg0, arg1)
+}
+
+// ParseSchedule mocks base method.
+func (m *MockScheduleParser) ParseSchedule(arg0 string, arg1 time.Time) (model.Scheduler, string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseSchedule", arg0, arg1)
+	ret0, _ := ret[0].(model.Scheduler)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// ParseSchedule indicates an expected call of ParseSchedule.
+func (mr *MockScheduleParserMockRecorder) ParseSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseSchedule", reflect.TypeOf((*MockScheduleParser)(nil).ParseSchedule), arg0, arg1)
+}
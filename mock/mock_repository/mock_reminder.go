@@ -12,6 +12,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/ww24/linebot/domain/model"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
 	return m.recorder
 }
 
+// Acknowledge mocks base method.
+func (m *MockReminder) Acknowledge(arg0 context.Context, arg1 model.ConversationID, arg2 model.ReminderItemID, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockReminderMockRecorder) Acknowledge(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockReminder)(nil).Acknowledge), arg0, arg1, arg2, arg3)
+}
+
 // Add mocks base method.
 func (m *MockReminder) Add(arg0 context.Context, arg1 *model.ReminderItem) error {
 	m.ctrl.T.Helper()
@@ -54,6 +69,20 @@ func (mr *MockReminderMockRecorder) Add(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReminder)(nil).Add), arg0, arg1)
 }
 
+// BeginDelivery mocks base method.
+func (m *MockReminder) BeginDelivery(arg0 context.Context, arg1 *model.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginDelivery indicates an expected call of BeginDelivery.
+func (mr *MockReminderMockRecorder) BeginDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginDelivery", reflect.TypeOf((*MockReminder)(nil).BeginDelivery), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockReminder) Delete(arg0 context.Context, arg1 model.ConversationID, arg2 model.ReminderItemID) error {
 	m.ctrl.T.Helper()
@@ -68,6 +97,20 @@ func (mr *MockReminderMockRecorder) Delete(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReminder)(nil).Delete), arg0, arg1, arg2)
 }
 
+// FinishDelivery mocks base method.
+func (m *MockReminder) FinishDelivery(arg0 context.Context, arg1 *model.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishDelivery indicates an expected call of FinishDelivery.
+func (mr *MockReminderMockRecorder) FinishDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishDelivery", reflect.TypeOf((*MockReminder)(nil).FinishDelivery), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockReminder) Get(arg0 context.Context, arg1 model.ConversationID, arg2 model.ReminderItemID) (*model.ReminderItem, error) {
 	m.ctrl.T.Helper()
@@ -99,16 +142,29 @@ func (mr *MockReminderMockRecorder) List(arg0, arg1 any) *gomock.Call {
 }
 
 // ListAll mocks base method.
-func (m *MockReminder) ListAll(arg0 context.Context) ([]*model.ReminderItem, error) {
+func (m *MockReminder) ListAll(arg0 context.Context, arg1 func(*model.ReminderItem) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAll", arg0)
-	ret0, _ := ret[0].([]*model.ReminderItem)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ListAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // ListAll indicates an expected call of ListAll.
-func (mr *MockReminderMockRecorder) ListAll(arg0 any) *gomock.Call {
+func (mr *MockReminderMockRecorder) ListAll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockReminder)(nil).ListAll), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockReminder) Update(arg0 context.Context, arg1 *model.ReminderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReminderMockRecorder) Update(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockReminder)(nil).ListAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReminder)(nil).Update), arg0, arg1)
 }
@@ -12,6 +12,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/ww24/linebot/domain/model"
 	gomock "go.uber.org/mock/gomock"
@@ -59,45 +60,221 @@ func (mr *MockShoppingMockRecorder) Add(arg0 any, arg1 ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockShopping)(nil).Add), varargs...)
 }
 
+// AddList mocks base method.
+func (m *MockShopping) AddList(arg0 context.Context, arg1 *model.ShoppingList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddList indicates an expected call of AddList.
+func (mr *MockShoppingMockRecorder) AddList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddList", reflect.TypeOf((*MockShopping)(nil).AddList), arg0, arg1)
+}
+
+// AddQuantity mocks base method.
+func (m *MockShopping) AddQuantity(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, id string, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddQuantity", ctx, conversationID, listID, id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddQuantity indicates an expected call of AddQuantity.
+func (mr *MockShoppingMockRecorder) AddQuantity(ctx, conversationID, listID, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQuantity", reflect.TypeOf((*MockShopping)(nil).AddQuantity), ctx, conversationID, listID, id, quantity)
+}
+
+// AppendHistory mocks base method.
+func (m *MockShopping) AppendHistory(arg0 context.Context, arg1 ...*model.PurchaseRecord) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AppendHistory", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendHistory indicates an expected call of AppendHistory.
+func (mr *MockShoppingMockRecorder) AppendHistory(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistory", reflect.TypeOf((*MockShopping)(nil).AppendHistory), varargs...)
+}
+
 // BatchDelete mocks base method.
-func (m *MockShopping) BatchDelete(ctx context.Context, conversationID model.ConversationID, ids []string) error {
+func (m *MockShopping) BatchDelete(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BatchDelete", ctx, conversationID, ids)
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, conversationID, listID, ids)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BatchDelete indicates an expected call of BatchDelete.
-func (mr *MockShoppingMockRecorder) BatchDelete(ctx, conversationID, ids any) *gomock.Call {
+func (mr *MockShoppingMockRecorder) BatchDelete(ctx, conversationID, listID, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockShopping)(nil).BatchDelete), ctx, conversationID, ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockShopping)(nil).BatchDelete), ctx, conversationID, listID, ids)
+}
+
+// Check mocks base method.
+func (m *MockShopping) Check(ctx context.Context, conversationID model.ConversationID, listID model.ShoppingListID, ids []string, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, conversationID, listID, ids, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockShoppingMockRecorder) Check(ctx, conversationID, listID, ids, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockShopping)(nil).Check), ctx, conversationID, listID, ids, t)
 }
 
 // DeleteAll mocks base method.
-func (m *MockShopping) DeleteAll(arg0 context.Context, arg1 model.ConversationID) error {
+func (m *MockShopping) DeleteAll(arg0 context.Context, arg1 model.ConversationID, arg2 model.ShoppingListID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAll", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteAll", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAll indicates an expected call of DeleteAll.
-func (mr *MockShoppingMockRecorder) DeleteAll(arg0, arg1 any) *gomock.Call {
+func (mr *MockShoppingMockRecorder) DeleteAll(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockShopping)(nil).DeleteAll), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockShopping)(nil).DeleteAll), arg0, arg1, arg2)
+}
+
+// DeleteList mocks base method.
+func (m *MockShopping) DeleteList(arg0 context.Context, arg1 model.ConversationID, arg2 model.ShoppingListID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockShoppingMockRecorder) DeleteList(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockShopping)(nil).DeleteList), arg0, arg1, arg2)
 }
 
 // Find mocks base method.
-func (m *MockShopping) Find(arg0 context.Context, arg1 model.ConversationID) ([]*model.ShoppingItem, error) {
+func (m *MockShopping) Find(arg0 context.Context, arg1 model.ConversationID, arg2 model.ShoppingListID) ([]*model.ShoppingItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", arg0, arg1)
+	ret := m.ctrl.Call(m, "Find", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*model.ShoppingItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockShoppingMockRecorder) Find(arg0, arg1 any) *gomock.Call {
+func (mr *MockShoppingMockRecorder) Find(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockShopping)(nil).Find), arg0, arg1, arg2)
+}
+
+// FindHistory mocks base method.
+func (m *MockShopping) FindHistory(ctx context.Context, conversationID model.ConversationID, since time.Time) ([]*model.PurchaseRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHistory", ctx, conversationID, since)
+	ret0, _ := ret[0].([]*model.PurchaseRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHistory indicates an expected call of FindHistory.
+func (mr *MockShoppingMockRecorder) FindHistory(ctx, conversationID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHistory", reflect.TypeOf((*MockShopping)(nil).FindHistory), ctx, conversationID, since)
+}
+
+// FindLists mocks base method.
+func (m *MockShopping) FindLists(arg0 context.Context, arg1 model.ConversationID) ([]*model.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLists", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLists indicates an expected call of FindLists.
+func (mr *MockShoppingMockRecorder) FindLists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLists", reflect.TypeOf((*MockShopping)(nil).FindLists), arg0, arg1)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockShopping) GetSnapshot(arg0 context.Context, arg1 model.ConversationID, arg2 model.ShoppingSnapshotID) (*model.ShoppingSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ShoppingSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockShoppingMockRecorder) GetSnapshot(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockShopping)(nil).GetSnapshot), arg0, arg1, arg2)
+}
+
+// RestoreSnapshot mocks base method.
+func (m *MockShopping) RestoreSnapshot(arg0 context.Context, arg1 model.ConversationID, arg2 model.ShoppingSnapshotID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockShoppingMockRecorder) RestoreSnapshot(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockShopping)(nil).RestoreSnapshot), arg0, arg1, arg2)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockShopping) SaveSnapshot(arg0 context.Context, arg1 *model.ShoppingSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockShoppingMockRecorder) SaveSnapshot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockShopping)(nil).SaveSnapshot), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockShopping) Update(arg0 context.Context, arg1 *model.ShoppingItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShoppingMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShopping)(nil).Update), arg0, arg1)
+}
+
+// UpdateList mocks base method.
+func (m *MockShopping) UpdateList(arg0 context.Context, arg1 *model.ShoppingList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateList indicates an expected call of UpdateList.
+func (mr *MockShoppingMockRecorder) UpdateList(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockShopping)(nil).Find), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateList", reflect.TypeOf((*MockShopping)(nil).UpdateList), arg0, arg1)
 }
@@ -80,18 +80,18 @@ func (m *MockWeatherImageStore) EXPECT() *MockWeatherImageStoreMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockWeatherImageStore) Get(arg0 context.Context, arg1 time.Time, arg2 time.Duration) (string, error) {
+func (m *MockWeatherImageStore) Get(arg0 context.Context, arg1 time.Time, arg2 *time.Location, arg3 time.Duration) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockWeatherImageStoreMockRecorder) Get(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockWeatherImageStoreMockRecorder) Get(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWeatherImageStore)(nil).Get), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWeatherImageStore)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
 // Save mocks base method.
@@ -0,0 +1,90 @@
+package nl
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/xerrors"
+)
+
+//go:embed category_dict.csv
+var categoryDictCSV string //nolint:gochecknoglobals
+
+// categoryDictionary maps a normalized base form to a category.
+type categoryDictionary map[string]string
+
+func newCategoryDictionary(path string) (categoryDictionary, error) {
+	dict := make(categoryDictionary)
+	if err := dict.load(strings.NewReader(categoryDictCSV)); err != nil {
+		return nil, xerrors.Errorf("failed to load embedded category dictionary: %w", err)
+	}
+	if path == "" {
+		return dict, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open category dictionary: %w", err)
+	}
+	defer f.Close()
+	if err := dict.load(f); err != nil {
+		return nil, xerrors.Errorf("failed to load category dictionary %s: %w", path, err)
+	}
+	return dict, nil
+}
+
+func (d categoryDictionary) load(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("failed to read csv: %w", err)
+		}
+		d[normalizeWord(record[0])] = strings.TrimSpace(record[1])
+	}
+}
+
+func (d categoryDictionary) lookup(word string) (string, bool) {
+	category, ok := d[normalizeWord(word)]
+	return category, ok
+}
+
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(word)))
+}
+
+// Category returns the category of the item name such as "野菜・果物", it returns "" if the name is unknown.
+// The last noun of the name which is in the dictionary decides the category, e.g. "無塩バター" is "乳製品・卵".
+func (p *Parser) Category(name string) string {
+	if category, ok := p.categories.lookup(name); ok {
+		return category
+	}
+
+	tokens := p.tokenizer.Tokenize(norm.NFKC.String(name))
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := &tokens[i]
+		if tokenPOS(token)[0] != posNoun {
+			continue
+		}
+		if bf, ok := token.BaseForm(); ok && bf != "*" {
+			if category, ok := p.categories.lookup(bf); ok {
+				return category
+			}
+		}
+		if category, ok := p.categories.lookup(token.Surface); ok {
+			return category
+		}
+	}
+	return ""
+}
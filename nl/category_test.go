@@ -0,0 +1,58 @@
+package nl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/internal/config"
+)
+
+func TestParser_Category(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "キャベツ", want: "野菜・果物"},
+		{src: "玉ねぎ", want: "野菜・果物"},
+		{src: "ﾊﾞﾅﾅ", want: "野菜・果物"},
+		{src: "豚肉", want: "肉・魚"},
+		{src: "鶏むね肉", want: "肉・魚"},
+		{src: "牛乳", want: "乳製品・卵"},
+		{src: "無塩バター", want: "乳製品・卵"},
+		{src: "トイレットペーパー", want: "日用品"},
+		{src: "食器用洗剤", want: "日用品"},
+		{src: "醤油", want: "調味料"},
+		{src: "電球", want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, p.Category(tt.src))
+		})
+	}
+}
+
+func TestParser_Category_UserDictionary(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "categories.csv")
+	data := "# user dictionary\n電球,日用品\n牛乳,飲料\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	p, err := NewParser(&config.NL{CategoryDictionary: path})
+	require.NoError(t, err)
+	assert.Equal(t, "日用品", p.Category("電球"))
+	assert.Equal(t, "飲料", p.Category("牛乳"))
+	assert.Equal(t, "野菜・果物", p.Category("キャベツ"))
+
+	_, err = NewParser(&config.NL{CategoryDictionary: filepath.Join(t.TempDir(), "not_found.csv")})
+	assert.Error(t, err)
+}
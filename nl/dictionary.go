@@ -0,0 +1,114 @@
+package nl
+
+import (
+	_ "embed"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+//go:embed user_dict.txt
+var userDictTXT string //nolint:gochecknoglobals
+
+// posUserWord is the part of speech of the words which conversations teach.
+const posUserWord = posNoun
+
+// loadUserDictRecords returns the records of the embedded user dictionary and the user dictionary of the path.
+func loadUserDictRecords(path string) (dict.UserDictRecords, error) {
+	records, err := dict.NewUserDicRecords(strings.NewReader(userDictTXT))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load embedded user dictionary: %w", err)
+	}
+	if path == "" {
+		return records, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open user dictionary: %w", err)
+	}
+	defer f.Close()
+	r, err := dict.NewUserDicRecords(f)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load user dictionary %s: %w", path, err)
+	}
+	return mergeUserDictRecords(records, r), nil
+}
+
+// mergeUserDictRecords merges the records, the later records override the records of the same text.
+func mergeUserDictRecords(records ...dict.UserDictRecords) dict.UserDictRecords {
+	index := make(map[string]int)
+	var ret dict.UserDictRecords
+	for _, rs := range records {
+		for _, r := range rs {
+			if i, ok := index[r.Text]; ok {
+				ret[i] = r
+				continue
+			}
+			index[r.Text] = len(ret)
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+func newTokenizer(records dict.UserDictRecords) (*tokenizer.Tokenizer, error) {
+	// NewUserDict sorts the records
+	records = append(dict.UserDictRecords(nil), records...)
+	udict, err := records.NewUserDict()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to build user dictionary: %w", err)
+	}
+	tk, err := tokenizer.New(ipa.Dict(), tokenizer.UserDict(udict), tokenizer.OmitBosEos())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to initialize tokenizer: %w", err)
+	}
+	return tk, nil
+}
+
+// withWords returns the parser which tokenizes the words of the conversation.
+func (p *Parser) withWords(words model.UserWords) (*Parser, error) {
+	if len(words) == 0 {
+		return p, nil
+	}
+	records := make(dict.UserDictRecords, 0, len(words))
+	for _, w := range words {
+		records = append(records, dict.UserDicRecord{
+			Text:   w.Surface,
+			Tokens: []string{w.Surface},
+			Yomi:   []string{w.Reading},
+			Pos:    posUserWord,
+		})
+	}
+	tk, err := newTokenizer(mergeUserDictRecords(p.userDict, records))
+	if err != nil {
+		return nil, err
+	}
+	parser := *p
+	parser.tokenizer = tk
+	return &parser, nil
+}
+
+// tokenPOS returns the part of speech of the token with 4 levels,
+// a token of the user dictionary has only the first level.
+func tokenPOS(t *tokenizer.Token) []string {
+	pos := t.POS()
+	for len(pos) < 4 {
+		pos = append(pos, "*")
+	}
+	return pos
+}
+
+// tokenReading returns the reading of the token including the tokens of the user dictionary.
+func tokenReading(t *tokenizer.Token) (string, bool) {
+	if extra := t.UserExtra(); extra != nil {
+		return strings.Join(extra.Readings, ""), true
+	}
+	return t.Reading()
+}
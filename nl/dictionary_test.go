@@ -0,0 +1,80 @@
+package nl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+)
+
+func TestParser_UserDictionary(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src  string
+		want *model.Item
+	}{
+		{
+			src:  "オイコスを削除",
+			want: &model.Item{Name: []string{"オイコス"}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "キッチンハイター買った",
+			want: &model.Item{Name: []string{"キッチンハイター"}, Action: model.ActionTypeCheck},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, p.Parse(tt.src))
+		})
+	}
+	assert.Equal(t, "ごごのこうちゃ", p.Normalize("午後の紅茶"))
+	assert.Equal(t, "日用品", p.Category("キッチンハイター"))
+}
+
+func TestParser_UserDictionary_Path(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "user_dict.txt")
+	data := "# user words\nモチモチパン,モチモチ パン,モチモチ パン,名詞\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	p, err := NewParser(&config.NL{UserDictionary: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, &model.Item{Name: []string{"モチモチパン"}, Action: model.ActionTypeCheck}, p.Parse("モチモチパン買った"))
+	// the embedded words are kept
+	assert.Equal(t, "おいこす", p.Normalize("オイコス"))
+
+	path = filepath.Join(t.TempDir(), "invalid.txt")
+	require.NoError(t, os.WriteFile(path, []byte("モチモチパン,モチモチ パン\n"), 0o600))
+	_, err = NewParser(&config.NL{UserDictionary: path})
+	require.Error(t, err)
+}
+
+func TestIntentClassifier_WithWords(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+	c, err := NewIntentClassifier(p, &config.NL{})
+	require.NoError(t, err)
+
+	word, err := model.NewUserWord("c1", "ふわとろ", "ふわとろ", time.Unix(1666416720, 0))
+	require.NoError(t, err)
+	wc, err := c.WithWords(model.UserWords{word})
+	require.NoError(t, err)
+
+	got := wc.Classify(model.LanguageJapanese, "ふわとろ買った")
+	assert.Equal(t, model.IntentTypeCheck, got.Type)
+	assert.Equal(t, []string{"ふわとろ"}, got.Item.Name)
+	// the words are not shared with the other conversations
+	assert.Empty(t, c.Classify(model.LanguageJapanese, "ふわとろ買った").Item.Name)
+}
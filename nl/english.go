@@ -0,0 +1,288 @@
+package nl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+//nolint:gochecknoglobals
+var (
+	englishRangeHyphen = regexp.MustCompile(`(\d)\s*[-~〜]\s*(\d)`)
+	englishOrdinal     = regexp.MustCompile(`^(\d+)(?:st|nd|rd|th)$`)
+	englishPunctuation = strings.NewReplacer(",", " , ", ";", " , ", "&", " and ", "#", " ")
+)
+
+//nolint:gochecknoglobals
+var englishNumbers = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "sixth": 6,
+	"seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10, "eleventh": 11, "twelfth": 12,
+}
+
+// englishLemmas maps the inflected words of the intent rules to the base forms.
+//
+//nolint:gochecknoglobals
+var englishLemmas = map[string]string{
+	"added": "add", "adding": "add", "adds": "add",
+	"puts": "put", "putting": "put", "lists": "list",
+	"reminders": "reminder", "reminded": "remind", "reminding": "remind",
+	"showed": "show", "shown": "show", "showing": "show",
+	"viewed": "view", "displayed": "display",
+}
+
+// englishStopWords are not a part of the names such as "the" and "from" of "delete the milk from the list".
+//
+//nolint:gochecknoglobals
+var englishStopWords = map[string]struct{}{
+	"a": {}, "an": {}, "the": {}, "my": {}, "our": {}, "please": {}, "it": {}, "them": {},
+	"from": {}, "off": {}, "to": {}, "on": {}, "onto": {}, "in": {}, "into": {},
+	"list": {}, "shopping": {}, "item": {}, "items": {}, "number": {}, "no": {},
+}
+
+// englishWord is a word of an English text, surface keeps the letter case of the text.
+type englishWord struct {
+	surface string
+	lower   string
+}
+
+// splitEnglish normalizes the text and splits it into words, "," and ";" are separate words.
+func splitEnglish(text string) []englishWord {
+	text = norm.NFKC.String(text)
+	text = englishRangeHyphen.ReplaceAllString(text, "$1 - $2")
+	text = englishPunctuation.Replace(text)
+
+	fields := strings.Fields(text)
+	words := make([]englishWord, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimRight(f, ".!?")
+		if f == "" {
+			continue
+		}
+		words = append(words, englishWord{surface: f, lower: strings.ToLower(f)})
+	}
+	return words
+}
+
+// englishRuleWords returns the words to match the intent rules.
+func englishRuleWords(words []englishWord) []word {
+	ret := make([]word, 0, len(words))
+	for _, w := range words {
+		base, ok := englishLemmas[w.lower]
+		if !ok {
+			base = w.lower
+		}
+		ret = append(ret, word{base: base, surface: w.lower})
+	}
+	return ret
+}
+
+// parseEnglish parses an English text such as "delete 1 and 3", "bought milk" and "rename 2 to butter".
+func parseEnglish(words []englishWord) *model.Item {
+	verb, action := -1, model.ActionTypeUnknown
+	for i := range words {
+		if at := englishAction(words[i].lower); at != model.ActionTypeUnknown {
+			verb, action = i, at
+			break
+		}
+	}
+
+	target := words
+	newName := ""
+	if action == model.ActionTypeUpdate {
+		// "rename 2 to butter" and "replace milk with soy milk"
+		for i := verb + 1; i < len(words); i++ {
+			if words[i].lower == "to" || words[i].lower == "with" {
+				target = words[:i]
+				newName = joinSurfaces(words[i+1:])
+				break
+			}
+		}
+	}
+
+	refs, consumed := parseEnglishReferences(target, action != model.ActionTypeUpdate)
+	item := &model.Item{
+		Indexes: refs.indexes,
+		Ranges:  refs.ranges,
+		All:     refs.all,
+		Action:  action,
+		NewName: newName,
+	}
+	for i := range consumed {
+		consumed[i] = consumed[i] || i == verb
+	}
+	item.Name = englishPhrases(target, consumed)
+	// "all" refers to every item only by itself, "delete all milk" deletes the milk
+	if len(item.Name) > 0 || len(item.Indexes) > 0 || len(item.Ranges) > 0 {
+		item.All = false
+	}
+	return item
+}
+
+// parseEnglishItems returns the items to add such as "milk" and "eggs 2 packs" of "add milk and eggs 2 packs to the list",
+// the items go between the verb and the last preposition.
+func parseEnglishItems(words []englishWord) []string {
+	verb, end := -1, -1
+	for i := range words {
+		switch words[i].lower {
+		case "add", "put":
+			if verb < 0 {
+				verb = i
+			}
+		case "to", "on", "onto", "in", "into":
+			if verb >= 0 {
+				end = i
+			}
+		}
+	}
+	if verb < 0 || end < 0 {
+		return nil
+	}
+
+	var items []string
+	begin := verb + 1
+	for i := verb + 1; i <= end; i++ {
+		if i < end && !isEnglishDelimiter(words[i].lower) {
+			continue
+		}
+		if item := joinSurfaces(words[begin:i]); item != "" {
+			items = append(items, item)
+		}
+		begin = i + 1
+	}
+	return items
+}
+
+func englishAction(word string) model.ActionType {
+	switch word {
+	case "delete", "deleted", "remove", "removed", "erase", "drop", "clear":
+		return model.ActionTypeDelete
+	// "buy milk" is not a check-off
+	case "bought", "got", "purchased", "check", "checked", "done":
+		return model.ActionTypeCheck
+	case "rename", "change", "edit", "update", "replace", "fix":
+		return model.ActionTypeUpdate
+	default:
+		return model.ActionTypeUnknown
+	}
+}
+
+func isEnglishDelimiter(word string) bool {
+	return word == "and" || word == "," || word == "or"
+}
+
+// parseEnglishReferences reads the references such as "2", "2nd", "two", "last", "all" and "1 to 3",
+// the returned slice reports whether each word is a part of the references.
+// A number followed by a name such as "2 eggs" is not a reference.
+func parseEnglishReferences(words []englishWord, ranges bool) (*references, []bool) {
+	refs := &references{}
+	consumed := make([]bool, len(words))
+	for i := 0; i < len(words); i++ {
+		switch words[i].lower {
+		case "all", "everything":
+			refs.all = true
+			consumed[i] = true
+			continue
+		}
+
+		from, ok := englishIndex(words, i)
+		if !ok {
+			continue
+		}
+		consumed[i] = true
+		// "one" of "the last one"
+		if i+1 < len(words) && words[i+1].lower == "one" {
+			consumed[i+1] = true
+			i++
+		}
+		if !ranges || i+2 >= len(words) {
+			refs.indexes = append(refs.indexes, from)
+			continue
+		}
+		switch words[i+1].lower {
+		case "-", "to", "through", "thru":
+		default:
+			refs.indexes = append(refs.indexes, from)
+			continue
+		}
+		to, ok := englishIndex(words, i+2)
+		if !ok {
+			refs.indexes = append(refs.indexes, from)
+			continue
+		}
+		consumed[i+1], consumed[i+2] = true, true
+		refs.ranges = append(refs.ranges, model.IndexRange{From: from, To: to})
+		i += 2
+	}
+	return refs, consumed
+}
+
+// englishIndex returns the index of the word at i if it is a reference.
+func englishIndex(words []englishWord, i int) (int, bool) {
+	w := words[i].lower
+	if w == "last" {
+		return -1, true
+	}
+	if m := englishOrdinal.FindStringSubmatch(w); m != nil {
+		w = m[1]
+	}
+	n, err := strconv.Atoi(w)
+	if err != nil {
+		var ok bool
+		if n, ok = englishNumbers[w]; !ok {
+			return 0, false
+		}
+	}
+	if n <= 0 {
+		return 0, false
+	}
+	// "2 eggs" is a quantity
+	if i+1 < len(words) {
+		next := words[i+1].lower
+		_, stop := englishStopWords[next]
+		_, number := englishNumbers[next]
+		if !stop && !number && !isEnglishDelimiter(next) && englishAction(next) == model.ActionTypeUnknown &&
+			next != "-" && next != "through" && next != "thru" && !isDigits(next) {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// englishPhrases returns the names such as "milk" and "soy sauce" of "delete milk and soy sauce from the list",
+// the stop words and the consumed words split the phrases.
+func englishPhrases(words []englishWord, consumed []bool) []string {
+	var names []string
+	begin := 0
+	for i := 0; i <= len(words); i++ {
+		if i < len(words) {
+			_, stop := englishStopWords[words[i].lower]
+			if !stop && !consumed[i] && !isEnglishDelimiter(words[i].lower) {
+				continue
+			}
+		}
+		if name := joinSurfaces(words[begin:i]); name != "" {
+			names = append(names, name)
+		}
+		begin = i + 1
+	}
+	return names
+}
+
+func joinSurfaces(words []englishWord) string {
+	surfaces := make([]string, 0, len(words))
+	for _, w := range words {
+		surfaces = append(surfaces, w.surface)
+	}
+	return strings.Join(surfaces, " ")
+}
+
+func isDigits(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
@@ -0,0 +1,142 @@
+package nl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+)
+
+func TestParseEnglish(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		src  string
+		want *model.Item
+	}{
+		{
+			src:  "delete 1",
+			want: &model.Item{Indexes: []int{1}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "Remove #2 and #3 from the list.",
+			want: &model.Item{Indexes: []int{2, 3}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete 1, 3",
+			want: &model.Item{Indexes: []int{1, 3}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete 1-3",
+			want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete from 2 to 4",
+			want: &model.Item{Ranges: []model.IndexRange{{From: 2, To: 4}}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete the last one",
+			want: &model.Item{Indexes: []int{-1}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete the 2nd item",
+			want: &model.Item{Indexes: []int{2}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete all",
+			want: &model.Item{All: true, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete all milk",
+			want: &model.Item{Name: []string{"milk"}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "delete Milk and soy sauce",
+			want: &model.Item{Name: []string{"Milk", "soy sauce"}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "remove 2 eggs",
+			want: &model.Item{Name: []string{"2 eggs"}, Action: model.ActionTypeDelete},
+		},
+		{
+			src:  "bought 2",
+			want: &model.Item{Indexes: []int{2}, Action: model.ActionTypeCheck},
+		},
+		{
+			src:  "bought milk",
+			want: &model.Item{Name: []string{"milk"}, Action: model.ActionTypeCheck},
+		},
+		{
+			src:  "buy milk",
+			want: &model.Item{Name: []string{"buy milk"}},
+		},
+		{
+			src:  "rename 2 to unsalted butter",
+			want: &model.Item{Indexes: []int{2}, Action: model.ActionTypeUpdate, NewName: "unsalted butter"},
+		},
+		{
+			src:  "replace milk with soy milk 2",
+			want: &model.Item{Name: []string{"milk"}, Action: model.ActionTypeUpdate, NewName: "soy milk 2"},
+		},
+		{
+			src:  "change 3",
+			want: &model.Item{Indexes: []int{3}, Action: model.ActionTypeUpdate},
+		},
+		{
+			src:  "",
+			want: &model.Item{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got := parseEnglish(splitEnglish(tt.src))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIntentClassifier_Classify_English(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+	c, err := NewIntentClassifier(p, &config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src       string
+		want      model.IntentType
+		wantItems []string
+	}{
+		{src: "shopping list", want: model.IntentTypeShowList},
+		{src: "List", want: model.IntentTypeShowList},
+		{src: "show me the list", want: model.IntentTypeShowList},
+		{src: "add milk and eggs 2 packs to the shopping list", want: model.IntentTypeAddItems, wantItems: []string{"milk", "eggs 2 packs"}},
+		{src: "put bread on the list", want: model.IntentTypeAddItems, wantItems: []string{"bread"}},
+		{src: "delete 1", want: model.IntentTypeDelete},
+		{src: "bought milk", want: model.IntentTypeCheck},
+		{src: "rename 2 to butter", want: model.IntentTypeUpdate},
+		{src: "Reminders", want: model.IntentTypeShowReminders},
+		{src: "remind me the shopping list tomorrow", want: model.IntentTypeSetReminder},
+		{src: "what's the weather today?", want: model.IntentTypeWeather},
+		{src: "help", want: model.IntentTypeHelp},
+		{src: "what can you do?", want: model.IntentTypeHelp},
+		// a sentence which contains a keyword is not a command
+		{src: "I forgot to add it", want: model.IntentTypeUnknown},
+		{src: "milk", want: model.IntentTypeUnknown},
+		{src: "", want: model.IntentTypeUnknown},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got := c.Classify(model.LanguageEnglish, tt.src)
+			assert.Equal(t, tt.want, got.Type)
+			assert.Equal(t, tt.wantItems, got.Items)
+			assert.NotNil(t, got.Item)
+		})
+	}
+}
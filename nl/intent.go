@@ -0,0 +1,277 @@
+package nl
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/internal/config"
+)
+
+//go:embed intent_rules.csv
+var intentRulesCSV string //nolint:gochecknoglobals
+
+//go:embed intent_rules_en.csv
+var englishIntentRulesCSV string //nolint:gochecknoglobals
+
+var errUnknownIntent = errors.New("unknown intent")
+
+//nolint:gochecknoglobals
+var intentTypes = map[string]model.IntentType{
+	"show_list":      model.IntentTypeShowList,
+	"add_items":      model.IntentTypeAddItems,
+	"show_reminders": model.IntentTypeShowReminders,
+	"set_reminder":   model.IntentTypeSetReminder,
+	"weather":        model.IntentTypeWeather,
+	"help":           model.IntentTypeHelp,
+}
+
+// IntentClassifier implements repository.IntentClassifier.
+// The keywords decide the intent by the rules, the actions of Parser decide the intent of the other texts.
+// English texts are classified by the English rules and parsed without the tokenizer.
+type IntentClassifier struct {
+	parser       *Parser
+	rules        []*intentRule
+	englishRules []*intentRule
+}
+
+func NewIntentClassifier(parser *Parser, conf *config.NL) (*IntentClassifier, error) {
+	var rules []*intentRule
+	if conf.IntentRules != "" {
+		f, err := os.Open(conf.IntentRules)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open intent rules: %w", err)
+		}
+		defer f.Close()
+		r, err := loadIntentRules(f)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load intent rules %s: %w", conf.IntentRules, err)
+		}
+		rules = append(rules, r...)
+	}
+	r, err := loadIntentRules(strings.NewReader(intentRulesCSV))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load embedded intent rules: %w", err)
+	}
+	rules = append(rules, r...)
+	englishRules, err := loadIntentRules(strings.NewReader(englishIntentRulesCSV))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load embedded English intent rules: %w", err)
+	}
+
+	return &IntentClassifier{
+		parser:       parser,
+		rules:        rules,
+		englishRules: englishRules,
+	}, nil
+}
+
+// WithWords returns the classifier which tokenizes the words of a conversation.
+func (c *IntentClassifier) WithWords(words model.UserWords) (repository.IntentClassifier, error) {
+	parser, err := c.parser.withWords(words)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to add user words: %w", err)
+	}
+	return &IntentClassifier{
+		parser:       parser,
+		rules:        c.rules,
+		englishRules: c.englishRules,
+	}, nil
+}
+
+func (c *IntentClassifier) Classify(lang model.Language, text string) *model.Intent {
+	if lang == model.LanguageEnglish {
+		return c.classifyEnglish(text)
+	}
+
+	str, tokens := c.parser.tokenize(text)
+	intent := &model.Intent{
+		Type: model.IntentTypeUnknown,
+		Item: c.parser.parse(str, tokens),
+	}
+	if len(tokens) == 0 {
+		return intent
+	}
+
+	words := tokenWords(tokens)
+	for _, rule := range c.rules {
+		if !rule.match(words) {
+			continue
+		}
+		if rule.intent == model.IntentTypeAddItems {
+			items := c.parser.parseNames(str, tokens, c.parser.parseReferences(tokens))
+			if len(items) == 0 {
+				continue
+			}
+			intent.Items = items
+		}
+		intent.Type = rule.intent
+		return intent
+	}
+
+	intent.Type = actionIntent(intent.Item.Action)
+	return intent
+}
+
+// classifyEnglish classifies an English text such as "add milk to the list" and "delete 2".
+func (c *IntentClassifier) classifyEnglish(text string) *model.Intent {
+	words := splitEnglish(text)
+	intent := &model.Intent{
+		Type: model.IntentTypeUnknown,
+		Item: parseEnglish(words),
+	}
+	if len(words) == 0 {
+		return intent
+	}
+
+	ruleWords := englishRuleWords(words)
+	for _, rule := range c.englishRules {
+		if !rule.match(ruleWords) {
+			continue
+		}
+		if rule.intent == model.IntentTypeAddItems {
+			items := parseEnglishItems(words)
+			if len(items) == 0 {
+				continue
+			}
+			intent.Items = items
+		}
+		intent.Type = rule.intent
+		return intent
+	}
+
+	intent.Type = actionIntent(intent.Item.Action)
+	return intent
+}
+
+func actionIntent(action model.ActionType) model.IntentType {
+	switch action {
+	case model.ActionTypeDelete:
+		return model.IntentTypeDelete
+	case model.ActionTypeCheck:
+		return model.IntentTypeCheck
+	case model.ActionTypeUpdate:
+		return model.IntentTypeUpdate
+	case model.ActionTypeUnknown:
+	}
+	return model.IntentTypeUnknown
+}
+
+type intentRule struct {
+	intent model.IntentType
+	// anchored reports whether the first term matches the beginning of the text.
+	anchored bool
+	// terms are the words in order, each term has alternative words.
+	terms [][]string
+}
+
+func loadIntentRules(r io.Reader) ([]*intentRule, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	var rules []*intentRule
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read csv: %w", err)
+		}
+		rule, err := parseIntentRule(record[0], record[1])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+}
+
+func parseIntentRule(name, pattern string) (*intentRule, error) {
+	intent, ok := intentTypes[strings.TrimSpace(name)]
+	if !ok {
+		return nil, xerrors.Errorf("%q: %w", name, errUnknownIntent)
+	}
+	rule := &intentRule{intent: intent}
+	for _, field := range strings.Fields(pattern) {
+		if field == "^" && len(rule.terms) == 0 {
+			rule.anchored = true
+			continue
+		}
+		words := strings.Split(field, "|")
+		for i := range words {
+			words[i] = normalizeWord(words[i])
+		}
+		rule.terms = append(rule.terms, words)
+	}
+	return rule, nil
+}
+
+// word is a unit of a text to match the rules, base and surface are normalized.
+type word struct {
+	base    string
+	surface string
+}
+
+func tokenWords(tokens []tokenizer.Token) []word {
+	words := make([]word, 0, len(tokens))
+	for i := range tokens {
+		bf, _ := tokens[i].BaseForm()
+		words = append(words, word{
+			base:    normalizeWord(bf),
+			surface: normalizeWord(tokens[i].Surface),
+		})
+	}
+	return words
+}
+
+// match reports whether the terms appear in the words in order.
+func (r *intentRule) match(words []word) bool {
+	pos := 0
+	for i, term := range r.terms {
+		found := false
+		for begin := pos; begin < len(words); begin++ {
+			if n := matchTerm(words[begin:], term); n > 0 {
+				pos, found = begin+n, true
+				break
+			}
+			if i == 0 && r.anchored {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTerm returns the number of the words which match one of the alternatives,
+// an alternative matches the base form of a word or the surfaces of the words such as "リマ" and "インド" of "リマインド".
+func matchTerm(words []word, alternatives []string) int {
+	for _, alt := range alternatives {
+		if words[0].base != "" && words[0].base == alt {
+			return 1
+		}
+		surface := ""
+		for i := range words {
+			surface += words[i].surface
+			if surface == alt {
+				return i + 1
+			}
+			if len(surface) >= len(alt) {
+				break
+			}
+		}
+	}
+	return 0
+}
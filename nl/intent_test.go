@@ -0,0 +1,83 @@
+package nl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+)
+
+func TestIntentClassifier_Classify(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+	c, err := NewIntentClassifier(p, &config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src       string
+		want      model.IntentType
+		wantItems []string
+	}{
+		{src: "買い物リスト", want: model.IntentTypeShowList},
+		{src: "買い物リスト コストコ", want: model.IntentTypeShowList},
+		{src: "買い物リストを見せて", want: model.IntentTypeShowList},
+		{src: "リストを表示して", want: model.IntentTypeShowList},
+		{src: "牛乳と卵を買い物リストに追加して", want: model.IntentTypeAddItems, wantItems: []string{"牛乳", "卵"}},
+		{src: "買い物リストに卵2パックを入れて", want: model.IntentTypeAddItems, wantItems: []string{"卵2パック"}},
+		{src: "1番を削除", want: model.IntentTypeDelete},
+		{src: "牛乳を消して", want: model.IntentTypeDelete},
+		{src: "2番買った", want: model.IntentTypeCheck},
+		{src: "2番をバター無塩に変更", want: model.IntentTypeUpdate},
+		{src: "リマインダー", want: model.IntentTypeShowReminders},
+		{src: "明日の8時に買い物リストをリマインド", want: model.IntentTypeSetReminder},
+		{src: "毎朝7時に天気をリマインド", want: model.IntentTypeSetReminder},
+		{src: "天気", want: model.IntentTypeWeather},
+		{src: "今日の天気は？", want: model.IntentTypeWeather},
+		{src: "明日の天気を教えて", want: model.IntentTypeWeather},
+		{src: "ヘルプ", want: model.IntentTypeHelp},
+		{src: "使い方", want: model.IntentTypeHelp},
+		{src: "何ができるの？", want: model.IntentTypeHelp},
+		{src: "ヘルプが必要です", want: model.IntentTypeHelp},
+		// a sentence which contains a keyword is not a command
+		{src: "昨日買い物リストに入れ忘れた", want: model.IntentTypeUnknown},
+		{src: "あとでヘルプします", want: model.IntentTypeUnknown},
+		{src: "天気のいい日に着る服を買い物リストに追加", want: model.IntentTypeAddItems, wantItems: []string{"着る服"}},
+		{src: "天気予報の本を買い物リストに追加", want: model.IntentTypeAddItems, wantItems: []string{"天気予報の本"}},
+		{src: "牛乳", want: model.IntentTypeUnknown},
+		{src: "", want: model.IntentTypeUnknown},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got := c.Classify(model.LanguageJapanese, tt.src)
+			assert.Equal(t, tt.want, got.Type)
+			assert.Equal(t, tt.wantItems, got.Items)
+			assert.NotNil(t, got.Item)
+		})
+	}
+}
+
+func TestIntentClassifier_Classify_UserRules(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "intent_rules.csv")
+	require.NoError(t, os.WriteFile(path, []byte("# user rules\nweather,^ 傘\n"), 0o600))
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+	c, err := NewIntentClassifier(p, &config.NL{IntentRules: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.IntentTypeWeather, c.Classify(model.LanguageJapanese, "傘いる？").Type)
+	assert.Equal(t, model.IntentTypeWeather, c.Classify(model.LanguageJapanese, "天気").Type)
+
+	path = filepath.Join(t.TempDir(), "invalid.csv")
+	require.NoError(t, os.WriteFile(path, []byte("unknown,傘\n"), 0o600))
+	_, err = NewIntentClassifier(p, &config.NL{IntentRules: path})
+	require.ErrorIs(t, err, errUnknownIntent)
+}
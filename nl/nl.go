@@ -1,11 +1,10 @@
 package nl
 
 import (
-	"strconv"
 	"strings"
 
 	"github.com/google/wire"
-	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/dict"
 	"github.com/ikawaha/kagome/v2/filter"
 	"github.com/ikawaha/kagome/v2/tokenizer"
 	"golang.org/x/text/unicode/norm"
@@ -13,22 +12,31 @@ import (
 
 	"github.com/ww24/linebot/domain/model"
 	"github.com/ww24/linebot/domain/repository"
+	"github.com/ww24/linebot/internal/config"
 )
 
 // Set provides a wire set.
 var Set = wire.NewSet(
 	NewParser,
 	wire.Bind(new(repository.NLParser), new(*Parser)),
+	NewIntentClassifier,
+	wire.Bind(new(repository.IntentClassifier), new(*IntentClassifier)),
+	NewScheduleParser,
+	wire.Bind(new(repository.ScheduleParser), new(*ScheduleParser)),
 )
 
 const (
 	// POS 1
-	posNoun = "名詞"
-	posVerb = "動詞"
+	posNoun     = "名詞"
+	posVerb     = "動詞"
+	posParticle = "助詞"
+	posSymbol   = "記号"
 
 	// POS 2
-	posNumeral = "数"  // 名詞, 数
-	posSuffix  = "接尾" // 名詞, 接尾
+	posNumeral  = "数"    // 名詞, 数
+	posSuffix   = "接尾"   // 名詞, 接尾
+	posComma    = "読点"   // 記号, 読点
+	posParallel = "並立助詞" // 助詞, 並立助詞
 
 	// POS 3
 	posQuantifier = "助数詞" // 名詞, 接尾, 助数詞
@@ -36,15 +44,26 @@ const (
 
 type Parser struct {
 	tokenizer *tokenizer.Tokenizer
-	allow     *filter.POSFilter
-	deny      *filter.POSFilter
-	replacer  *strings.Replacer
+	// userDict is the records of the user dictionary, the words of a conversation are added to them.
+	userDict   dict.UserDictRecords
+	allow      *filter.POSFilter
+	deny       *filter.POSFilter
+	replacer   *strings.Replacer
+	categories categoryDictionary
 }
 
-func NewParser() (*Parser, error) {
-	tk, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+func NewParser(conf *config.NL) (*Parser, error) {
+	userDict, err := loadUserDictRecords(conf.UserDictionary)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to initialize tokenizer: %w", err)
+		return nil, xerrors.Errorf("failed to initialize user dictionary: %w", err)
+	}
+	tk, err := newTokenizer(userDict)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := newCategoryDictionary(conf.CategoryDictionary)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to initialize category dictionary: %w", err)
 	}
 
 	allowFilter := filter.NewPOSFilter(
@@ -57,27 +76,121 @@ func NewParser() (*Parser, error) {
 	replacer := strings.NewReplacer(",", "、")
 
 	return &Parser{
-		tokenizer: tk,
-		allow:     allowFilter,
-		deny:      denyFilter,
-		replacer:  replacer,
+		tokenizer:  tk,
+		userDict:   userDict,
+		allow:      allowFilter,
+		deny:       denyFilter,
+		replacer:   replacer,
+		categories: categories,
 	}, nil
 }
 
 func (p *Parser) Parse(str string) *model.Item {
+	str, tokens := p.tokenize(str)
+	return p.parse(str, tokens)
+}
+
+// tokenize normalizes the text and splits it into tokens.
+func (p *Parser) tokenize(str string) (string, []tokenizer.Token) {
 	str = norm.NFKC.String(str)
 	str = p.replacer.Replace(str)
-	tokens := p.tokenizer.Tokenize(str)
+	return str, p.tokenizer.Tokenize(str)
+}
+
+func (p *Parser) parse(str string, tokens []tokenizer.Token) *model.Item {
+	if item, ok := p.parseUpdate(str, tokens); ok {
+		return item
+	}
+	completed := p.isCompleted(tokens)
 
 	// debug code
 	// for _, token := range tokens {
 	// 	fmt.Printf("%+v, %+v\n", token.Surface, token.Features())
 	// }
 
+	refs := p.parseReferences(tokens)
+	item := p.parseTokens(append([]tokenizer.Token(nil), tokens...), refs)
+	if names := p.parseNames(str, tokens, refs); len(names) > 0 {
+		item.Name = names
+	}
+	// "全部" refers to every item only by itself, "牛乳を全部削除" deletes the milk
+	if len(item.Name) > 0 || len(item.Indexes) > 0 || len(item.Ranges) > 0 {
+		item.All = false
+	}
+
+	// "牛乳を買う" is not a check-off
+	if item.Action == model.ActionTypeCheck && !completed {
+		item.Action = model.ActionTypeUnknown
+	}
+
+	return item
+}
+
+// parseUpdate parses an edit such as "2番をバター無塩に変更",
+// the target goes before "を" and the new name goes between "を" and "に".
+func (p *Parser) parseUpdate(str string, tokens []tokenizer.Token) (*model.Item, bool) {
+	verb := -1
+	for i := range tokens {
+		if p.selectAction(&tokens[i]) == model.ActionTypeUpdate {
+			verb = i
+			break
+		}
+	}
+	if verb < 1 || !isParticle(&tokens[verb-1], "に") {
+		return nil, false
+	}
+	object := -1
+	for i := verb - 2; i > 0; i-- {
+		if isParticle(&tokens[i], "を") {
+			object = i
+			break
+		}
+	}
+	if object < 0 {
+		return nil, false
+	}
+	begin := tokens[object].Position + len(tokens[object].Surface)
+	newName := strings.TrimSpace(str[begin:tokens[verb-1].Position])
+	if newName == "" {
+		return nil, false
+	}
+
+	target := make([]tokenizer.Token, object)
+	copy(target, tokens[:object])
+	item := p.parseTokens(target, p.parseReferences(target))
+	item.Action = model.ActionTypeUpdate
+	item.NewName = newName
+	return item, true
+}
+
+// isDelimiter reports whether the token separates names such as "と" and "、".
+func isDelimiter(t *tokenizer.Token) bool {
+	pos := tokenPOS(t)
+	switch {
+	case pos[0] == posParticle:
+		// "と" after a number is tagged as a case particle
+		return pos[1] == posParallel || t.Surface == "と" || t.Surface == "や"
+	case pos[0] == posSymbol:
+		return pos[1] == posComma
+	default:
+		return false
+	}
+}
+
+func isParticle(t *tokenizer.Token, surface string) bool {
+	return t.Surface == surface && tokenPOS(t)[0] == posParticle
+}
+
+// parseTokens collects the names and the action of the tokens, the indexes are taken from the references.
+func (p *Parser) parseTokens(tokens []tokenizer.Token, refs *references) *model.Item {
 	p.allow.Keep(&tokens)
 	p.deny.Drop(&tokens)
 
-	item := new(model.Item)
+	item := &model.Item{
+		Indexes: refs.indexes,
+		Ranges:  refs.ranges,
+		All:     refs.all,
+	}
 	for i := range tokens {
 		token := &tokens[i]
 		at := p.selectAction(token)
@@ -86,20 +199,15 @@ func (p *Parser) Parse(str string) *model.Item {
 			continue
 		}
 
-		pos := token.POS()
+		pos := tokenPOS(token)
 		switch pos[0] {
 		case posNoun:
-			if pos[1] == posNumeral {
-				idx, err := p.parseNumber(token.Surface)
-				if err != nil {
-					continue
-				}
-				item.Indexes = append(item.Indexes, idx)
+			if pos[1] == posNumeral || refs.isConsumed(token) {
 				continue
 			}
 
 			// add name if reading feature exists
-			if _, ok := token.Reading(); ok {
+			if _, ok := tokenReading(token); ok {
 				item.Name = append(item.Name, token.Surface)
 			}
 		}
@@ -108,20 +216,59 @@ func (p *Parser) Parse(str string) *model.Item {
 	return item
 }
 
-func (*Parser) parseNumber(str string) (int, error) {
-	// 漢数字対応
-	var cn = [...]string{"一", "二", "三", "四", "五", "六", "七", "八", "九"}
-	num, err := strconv.Atoi(str)
-	if err != nil {
-		for i, n := range cn {
-			if str == n {
-				return i + 1, nil
-			}
+// parseNames returns the phrases of the object such as "無塩バター" and "卵" in "無塩バターと卵を削除",
+// phrases of references such as "1番" and "最後の" are omitted.
+func (*Parser) parseNames(str string, tokens []tokenizer.Token, refs *references) []string {
+	object := -1
+	for i := range tokens {
+		if isParticle(&tokens[i], "を") {
+			object = i
+			break
 		}
+	}
+	if object < 0 {
+		return nil
+	}
 
-		return 0, xerrors.Errorf("failed to convert Chinese numeral: %w", err)
+	var names []string
+	begin, index := 0, true
+	for i := 0; i <= object; i++ {
+		token := &tokens[i]
+		// "買い物リストに" of "買い物リストに牛乳を追加" is not a name
+		if isParticle(token, "に") {
+			begin, index = i+1, true
+			continue
+		}
+		if i < object && !isDelimiter(token) {
+			index = index && refs.isConsumed(token)
+			continue
+		}
+		if name := strings.TrimSpace(str[tokens[begin].Position:token.Position]); name != "" && !index {
+			names = append(names, name)
+		}
+		begin, index = i+1, true
+	}
+	return names
+}
+
+// isCompleted reports whether the tokens have a past form of buying like "買った", "購入しました" and "購入済み".
+func (*Parser) isCompleted(tokens []tokenizer.Token) bool {
+	for i := range tokens {
+		if bf, _ := tokens[i].BaseForm(); bf != "買う" && bf != "購入" {
+			continue
+		}
+		for j := i + 1; j < len(tokens); j++ {
+			bf, _ := tokens[j].BaseForm()
+			switch bf {
+			case "た", "済み":
+				return true
+			case "する", "ます":
+				continue
+			}
+			break
+		}
 	}
-	return num, nil
+	return false
 }
 
 func (*Parser) selectAction(t *tokenizer.Token) model.ActionType {
@@ -135,6 +282,10 @@ func (*Parser) selectAction(t *tokenizer.Token) model.ActionType {
 	switch keyword {
 	case "削除", "除去", "消す":
 		return model.ActionTypeDelete
+	case "買う", "購入", "済み":
+		return model.ActionTypeCheck
+	case "変更", "変える", "修正", "直す", "訂正":
+		return model.ActionTypeUpdate
 	default:
 		return model.ActionTypeUnknown
 	}
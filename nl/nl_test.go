@@ -7,11 +7,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
 )
 
 func TestParser_Parse(t *testing.T) {
 	t.Parallel()
-	p, err := NewParser()
+	p, err := NewParser(&config.NL{})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -123,6 +124,113 @@ func TestParser_Parse(t *testing.T) {
 				Action:  model.ActionTypeDelete,
 			},
 		},
+		{
+			src: "2番買った",
+			want: &model.Item{
+				Indexes: []int{2},
+				Action:  model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "1と3買いました",
+			want: &model.Item{
+				Indexes: []int{1, 3},
+				Action:  model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "牛乳を買った",
+			want: &model.Item{
+				Name:   []string{"牛乳"},
+				Action: model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "2番を購入しました",
+			want: &model.Item{
+				Indexes: []int{2},
+				Action:  model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "2番を購入済みに",
+			want: &model.Item{
+				Indexes: []int{2},
+				Action:  model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "買った",
+			want: &model.Item{
+				Action: model.ActionTypeCheck,
+			},
+		},
+		{
+			src: "牛乳を買う",
+			want: &model.Item{
+				Name: []string{"牛乳"},
+			},
+		},
+		{
+			src: "2番をバター無塩に変更",
+			want: &model.Item{
+				Indexes: []int{2},
+				Action:  model.ActionTypeUpdate,
+				NewName: "バター無塩",
+			},
+		},
+		{
+			src: "牛乳を豆乳 2本に変えて",
+			want: &model.Item{
+				Name:    []string{"牛乳"},
+				Action:  model.ActionTypeUpdate,
+				NewName: "豆乳 2本",
+			},
+		},
+		{
+			src: "3番を卵に修正してください。",
+			want: &model.Item{
+				Indexes: []int{3},
+				Action:  model.ActionTypeUpdate,
+				NewName: "卵",
+			},
+		},
+		{
+			src: "2番を変更",
+			want: &model.Item{
+				Indexes: []int{2},
+				Action:  model.ActionTypeUpdate,
+			},
+		},
+		{
+			src: "無塩バターを削除",
+			want: &model.Item{
+				Name:   []string{"無塩バター"},
+				Action: model.ActionTypeDelete,
+			},
+		},
+		{
+			src: "ぎゅうにゅうを消して",
+			want: &model.Item{
+				Name:   []string{"ぎゅうにゅう"},
+				Action: model.ActionTypeDelete,
+			},
+		},
+		{
+			src: "牛乳とパンを削除",
+			want: &model.Item{
+				Name:   []string{"牛乳", "パン"},
+				Action: model.ActionTypeDelete,
+			},
+		},
+		{
+			src: "1番と卵を削除",
+			want: &model.Item{
+				Indexes: []int{1},
+				Name:    []string{"卵"},
+				Action:  model.ActionTypeDelete,
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -133,3 +241,76 @@ func TestParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ParseQuantity(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src          string
+		wantName     string
+		wantQuantity int
+		wantUnit     string
+	}{
+		{src: "卵 2パック", wantName: "卵", wantQuantity: 2, wantUnit: "パック"},
+		{src: "卵2パック", wantName: "卵", wantQuantity: 2, wantUnit: "パック"},
+		{src: "牛乳x3", wantName: "牛乳", wantQuantity: 3},
+		{src: "牛乳 × 3本", wantName: "牛乳", wantQuantity: 3, wantUnit: "本"},
+		{src: "牛乳ｘ３", wantName: "牛乳", wantQuantity: 3},
+		{src: "ティッシュBOX 5", wantName: "ティッシュBOX", wantQuantity: 5},
+		{src: "Xbox 2", wantName: "Xbox", wantQuantity: 2},
+		{src: "Xbox x2", wantName: "Xbox", wantQuantity: 2},
+		{src: "BOX×2", wantName: "BOX", wantQuantity: 2},
+		{src: "りんご三個", wantName: "りんご", wantQuantity: 3, wantUnit: "個"},
+		{src: "卵十個", wantName: "卵", wantQuantity: 10, wantUnit: "個"},
+		{src: "みかん二十三個", wantName: "みかん", wantQuantity: 23, wantUnit: "個"},
+		{src: "十六茶2本", wantName: "十六茶", wantQuantity: 2, wantUnit: "本"},
+		{src: "ティッシュ 5", wantName: "ティッシュ", wantQuantity: 5},
+		{src: "ポカリ 500ml", wantName: "ポカリ", wantQuantity: 500, wantUnit: "ml"},
+		{src: "玉ねぎ", wantName: "玉ねぎ", wantQuantity: 1},
+		{src: "三つ葉", wantName: "三つ葉", wantQuantity: 1},
+		// a number without a separator or a unit is a part of the name
+		{src: "iPhone15", wantName: "iPhone15", wantQuantity: 1},
+		{src: "牛乳x0", wantName: "牛乳x0", wantQuantity: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			name, quantity, unit := p.ParseQuantity(tt.src)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantQuantity, quantity)
+			assert.Equal(t, tt.wantUnit, unit)
+		})
+	}
+}
+
+func TestParser_Normalize(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "牛乳", want: "ぎゅうにゅう"},
+		{src: "ぎゅうにゅう", want: "ぎゅうにゅう"},
+		{src: "ギュウニュウ", want: "ぎゅうにゅう"},
+		{src: "ｷﾞｭｳﾆｭｳ", want: "ぎゅうにゅう"},
+		{src: "玉ねぎ", want: "たまねぎ"},
+		{src: "たまご", want: "たまご"},
+		{src: "卵", want: "たまご"},
+		{src: "ﾊﾟﾝ", want: "ぱん"},
+		{src: "トイレット ペーパー", want: "といれっとぺーぱー"},
+		{src: "ＡＢＣ", want: "abc"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, p.Normalize(tt.src))
+		})
+	}
+}
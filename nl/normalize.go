@@ -0,0 +1,44 @@
+package nl
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	katakanaSmallA = 'ァ'
+	katakanaVu     = 'ヴ'
+	// kanaOffset is the distance between a katakana and the hiragana.
+	kanaOffset = 'ァ' - 'ぁ'
+)
+
+// Normalize returns the reading of the name in hiragana,
+// names are comparable regardless of kana, kanji and width, e.g. "牛乳", "ぎゅうにゅう" and "ｷﾞｭｳﾆｭｳ".
+// Words which are not in the dictionary are kept as they are.
+func (p *Parser) Normalize(name string) string {
+	name = strings.ToLower(norm.NFKC.String(name))
+	var b strings.Builder
+	for _, token := range p.tokenizer.Tokenize(name) {
+		if r, ok := tokenReading(&token); ok && r != "*" {
+			b.WriteString(r)
+			continue
+		}
+		b.WriteString(token.Surface)
+	}
+	return toHiragana(b.String())
+}
+
+func toHiragana(str string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		case katakanaSmallA <= r && r <= katakanaVu:
+			return r - kanaOffset
+		default:
+			return r
+		}
+	}, str)
+}
@@ -0,0 +1,244 @@
+package nl
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"golang.org/x/xerrors"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+var errInvalidNumber = errors.New("invalid number")
+
+// references holds the references to items in a text such as "1番", "十二番目", "1から3" and "最後".
+type references struct {
+	indexes []int
+	ranges  []model.IndexRange
+	all     bool
+	// consumed holds the Index of the tokens which are a part of the references.
+	consumed map[int]struct{}
+}
+
+func (r *references) isConsumed(t *tokenizer.Token) bool {
+	_, ok := r.consumed[t.Index]
+	return ok
+}
+
+// referenceScanner reads the references from tokens.
+type referenceScanner struct {
+	parser *Parser
+	tokens []tokenizer.Token
+	pos    int
+	refs   *references
+}
+
+func (p *Parser) parseReferences(tokens []tokenizer.Token) *references {
+	s := &referenceScanner{
+		parser: p,
+		tokens: tokens,
+		refs:   &references{consumed: make(map[int]struct{})},
+	}
+	for s.pos < len(s.tokens) {
+		switch s.peek().Surface {
+		case "全部", "全て", "すべて", "ぜんぶ":
+			s.refs.all = true
+			s.consume()
+			continue
+		}
+
+		from, ok := s.scanTerm()
+		if !ok {
+			s.pos++
+			continue
+		}
+		if !s.scanRangeMarker() {
+			s.refs.indexes = append(s.refs.indexes, from)
+			continue
+		}
+		to, ok := s.scanTerm()
+		if !ok {
+			// "1から" without the end
+			s.refs.indexes = append(s.refs.indexes, from)
+			continue
+		}
+		s.scanSurface("まで")
+		s.refs.ranges = append(s.refs.ranges, model.IndexRange{From: from, To: to})
+	}
+	return s.refs
+}
+
+func (s *referenceScanner) peek() *tokenizer.Token {
+	if s.pos >= len(s.tokens) {
+		return nil
+	}
+	return &s.tokens[s.pos]
+}
+
+func (s *referenceScanner) consume() {
+	s.refs.consumed[s.tokens[s.pos].Index] = struct{}{}
+	s.pos++
+}
+
+// scanSurface consumes the next token if it is one of the surfaces.
+func (s *referenceScanner) scanSurface(surfaces ...string) bool {
+	t := s.peek()
+	if t == nil {
+		return false
+	}
+	for _, surface := range surfaces {
+		if t.Surface == surface {
+			s.consume()
+			return true
+		}
+	}
+	return false
+}
+
+// scanRangeMarker consumes "から" and "〜" between the ends of a range.
+func (s *referenceScanner) scanRangeMarker() bool {
+	t := s.peek()
+	if t == nil {
+		return false
+	}
+	if isParticle(t, "から") {
+		s.consume()
+		return true
+	}
+	return s.scanSurface("〜", "~", "-", "−")
+}
+
+// scanTerm reads an index such as "1", "十二番目", "1つ目" and "最後",
+// a number followed by a counter such as "2パック" is not an index.
+func (s *referenceScanner) scanTerm() (int, bool) {
+	t := s.peek()
+	if t == nil {
+		return 0, false
+	}
+	switch t.Surface {
+	case "最後", "最終":
+		s.consume()
+		s.scanSurface("の")
+		return -1, true
+	case "最初":
+		s.consume()
+		s.scanSurface("の")
+		return 1, true
+	}
+
+	begin := s.pos
+	var b strings.Builder
+	for t := s.peek(); t != nil && isNumeral(t); t = s.peek() {
+		b.WriteString(t.Surface)
+		s.pos++
+	}
+	// "一番" is a single token
+	ordinal := false
+	if t := s.peek(); t != nil && (t.Surface == "一番" || t.Surface == "一番目") {
+		b.WriteString("一")
+		s.pos++
+		ordinal = true
+	}
+	if s.pos == begin {
+		return 0, false
+	}
+	num, err := s.parser.parseNumber(b.String())
+	if err != nil {
+		s.pos = begin
+		return 0, false
+	}
+	if !ordinal && s.isCounter() {
+		return 0, false
+	}
+	for i := begin; i < s.pos; i++ {
+		s.refs.consumed[s.tokens[i].Index] = struct{}{}
+	}
+	if !ordinal {
+		s.scanOrdinalSuffix()
+	}
+	return num, true
+}
+
+// isCounter reports whether the next token is a counter of quantities such as "パック" and "本".
+func (s *referenceScanner) isCounter() bool {
+	t := s.peek()
+	if t == nil {
+		return false
+	}
+	pos := tokenPOS(t)
+	if pos[0] != posNoun || pos[1] != posSuffix || pos[2] != posQuantifier {
+		return false
+	}
+	return t.Surface != "番" && t.Surface != "番目"
+}
+
+// scanOrdinalSuffix consumes "番", "番目" and "つ目".
+func (s *referenceScanner) scanOrdinalSuffix() {
+	if s.scanSurface("番目") {
+		return
+	}
+	if s.scanSurface("番") {
+		s.scanSurface("目")
+		return
+	}
+	if t := s.peek(); t != nil && t.Surface == "つ" && s.pos+1 < len(s.tokens) && s.tokens[s.pos+1].Surface == "目" {
+		s.consume()
+		s.consume()
+	}
+}
+
+func isNumeral(t *tokenizer.Token) bool {
+	pos := tokenPOS(t)
+	return pos[0] == posNoun && pos[1] == posNumeral
+}
+
+//nolint:gochecknoglobals
+var kanjiDigits = map[rune]int{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+//nolint:gochecknoglobals
+var kanjiMultipliers = map[rune]int{
+	'十': 10, '百': 100, '千': 1000,
+}
+
+// parseNumber converts a number such as "12", "十二" and "二十三" into int.
+func (*Parser) parseNumber(str string) (int, error) {
+	if num, err := strconv.Atoi(str); err == nil {
+		return num, nil
+	}
+
+	total, current := 0, -1
+	for _, r := range str {
+		if d, ok := kanjiDigits[r]; ok {
+			if current < 0 {
+				current = 0
+			}
+			current = current*10 + d
+			continue
+		}
+		if '0' <= r && r <= '9' {
+			if current < 0 {
+				current = 0
+			}
+			current = current*10 + int(r-'0')
+			continue
+		}
+		m, ok := kanjiMultipliers[r]
+		if !ok {
+			return 0, xerrors.Errorf("%q: %w", str, errInvalidNumber)
+		}
+		if current < 0 {
+			current = 1
+		}
+		total += current * m
+		current = -1
+	}
+	if current >= 0 {
+		total += current
+	}
+	return total, nil
+}
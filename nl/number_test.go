@@ -0,0 +1,110 @@
+package nl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ww24/linebot/domain/model"
+	"github.com/ww24/linebot/internal/config"
+)
+
+func TestParser_parseNumber(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src     string
+		want    int
+		wantErr bool
+	}{
+		{src: "1", want: 1},
+		{src: "12", want: 12},
+		{src: "一", want: 1},
+		{src: "九", want: 9},
+		{src: "十", want: 10},
+		{src: "十一", want: 11},
+		{src: "十二", want: 12},
+		{src: "二十", want: 20},
+		{src: "二十三", want: 23},
+		{src: "九十九", want: 99},
+		{src: "百", want: 100},
+		{src: "百一", want: 101},
+		{src: "三百五十", want: 350},
+		{src: "千二百", want: 1200},
+		{src: "一〇", want: 10},
+		{src: "〇", want: 0},
+		{src: "2十", want: 20},
+		{src: "", want: 0},
+		{src: "牛乳", wantErr: true},
+		{src: "十a", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got, err := p.parseNumber(tt.src)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParser_Parse_References(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser(&config.NL{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		src  string
+		want *model.Item
+	}{
+		// compound kanji numerals
+		{src: "十番を削除", want: &model.Item{Indexes: []int{10}, Action: model.ActionTypeDelete}},
+		{src: "十二番を削除", want: &model.Item{Indexes: []int{12}, Action: model.ActionTypeDelete}},
+		{src: "二十三番目を削除", want: &model.Item{Indexes: []int{23}, Action: model.ActionTypeDelete}},
+		{src: "一番を削除", want: &model.Item{Indexes: []int{1}, Action: model.ActionTypeDelete}},
+		{src: "百一番を削除", want: &model.Item{Indexes: []int{101}, Action: model.ActionTypeDelete}},
+		{src: "三と十一を消す", want: &model.Item{Indexes: []int{3, 11}, Action: model.ActionTypeDelete}},
+		{src: "１２番を削除", want: &model.Item{Indexes: []int{12}, Action: model.ActionTypeDelete}},
+		{src: "1つ目を削除", want: &model.Item{Indexes: []int{1}, Action: model.ActionTypeDelete}},
+		{src: "2番目と4番目を削除", want: &model.Item{Indexes: []int{2, 4}, Action: model.ActionTypeDelete}},
+		// ranges
+		{src: "1から3番を削除", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete}},
+		{src: "1番から3番まで削除", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete}},
+		{src: "1〜3を削除", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete}},
+		{src: "1～3を削除", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete}},
+		{src: "1-3を削除", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeDelete}},
+		{src: "五から七を削除", want: &model.Item{Ranges: []model.IndexRange{{From: 5, To: 7}}, Action: model.ActionTypeDelete}},
+		{src: "1と3から5を削除", want: &model.Item{Indexes: []int{1}, Ranges: []model.IndexRange{{From: 3, To: 5}}, Action: model.ActionTypeDelete}},
+		{src: "1から3番買った", want: &model.Item{Ranges: []model.IndexRange{{From: 1, To: 3}}, Action: model.ActionTypeCheck}},
+		// all
+		{src: "全部削除", want: &model.Item{All: true, Action: model.ActionTypeDelete}},
+		{src: "全部を削除", want: &model.Item{All: true, Action: model.ActionTypeDelete}},
+		{src: "すべて削除", want: &model.Item{All: true, Action: model.ActionTypeDelete}},
+		{src: "全て買った", want: &model.Item{All: true, Action: model.ActionTypeCheck}},
+		{src: "牛乳を全部削除", want: &model.Item{Name: []string{"牛乳"}, Action: model.ActionTypeDelete}},
+		// relative references
+		{src: "最後を削除", want: &model.Item{Indexes: []int{-1}, Action: model.ActionTypeDelete}},
+		{src: "最後のを削除", want: &model.Item{Indexes: []int{-1}, Action: model.ActionTypeDelete}},
+		{src: "最初を削除", want: &model.Item{Indexes: []int{1}, Action: model.ActionTypeDelete}},
+		{src: "2番から最後まで削除", want: &model.Item{Ranges: []model.IndexRange{{From: 2, To: -1}}, Action: model.ActionTypeDelete}},
+		// a number with a counter is a quantity
+		{src: "卵2パックを削除", want: &model.Item{Name: []string{"卵2パック"}, Action: model.ActionTypeDelete}},
+		{src: "三つ葉を削除", want: &model.Item{Name: []string{"三つ葉"}, Action: model.ActionTypeDelete}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got := p.Parse(tt.src)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package nl
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// compound kanji numerals such as "十二" are converted by parseNumber
+	quantityNumber = `(\d+|[〇一二三四五六七八九十百千]+)`
+	// longer units go first to be matched greedily
+	quantityUnit = `(個入り|パック|セット|ケース|リットル|グラム|キロ|切れ|個|コ|つ|本|枚|袋|箱|缶|瓶|束|玉|株|切|房|尾|匹|杯|台|足|組|合|丁|粒|kg|ml|g|l|L)`
+)
+
+//nolint:gochecknoglobals
+var (
+	// 牛乳x3, 卵 ×2パック
+	// "x" is a multiplier only at the beginning of a word, "x" of "Xbox 2" is a part of the name
+	quantityMultiply = regexp.MustCompile(`^(.+?)\s*(?:[×*]|\b[xX])\s*` + quantityNumber + `\s*` + quantityUnit + `?$`)
+	// 卵 2パック, りんご三個
+	quantityCounter = regexp.MustCompile(`^(.+?)\s*` + quantityNumber + `\s*` + quantityUnit + `$`)
+	// 牛乳 3
+	quantityNumberOnly = regexp.MustCompile(`^(.+?)\s+(\d+)$`)
+)
+
+// ParseQuantity splits a line of a shopping item into the name, the quantity and the unit.
+// The quantity is 1 and the unit is empty if the line has no quantity.
+func (p *Parser) ParseQuantity(line string) (string, int, string) {
+	line = strings.TrimSpace(norm.NFKC.String(line))
+	for _, re := range []*regexp.Regexp{quantityMultiply, quantityCounter, quantityNumberOnly} {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := strings.TrimSpace(m[1])
+		num, err := p.parseNumber(m[2])
+		if err != nil || num <= 0 || name == "" {
+			continue
+		}
+		var unit string
+		if len(m) > 3 {
+			unit = m[3]
+		}
+		return name, num, unit
+	}
+	return line, 1, ""
+}
@@ -0,0 +1,359 @@
+package nl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+const (
+	// defaultScheduleHour is used when the text has a date but no clock.
+	defaultScheduleHour = 9
+	lastDayOfMonth      = 31
+	hoursPerHalfDay     = 12
+	halfHour            = 30
+)
+
+//nolint:gochecknoglobals
+var japaneseWeekdays = map[string]time.Weekday{
+	"日": time.Sunday,
+	"月": time.Monday,
+	"火": time.Tuesday,
+	"水": time.Wednesday,
+	"木": time.Thursday,
+	"金": time.Friday,
+	"土": time.Saturday,
+}
+
+// ScheduleParser extracts a date/time expression from Japanese text.
+type ScheduleParser struct {
+	relative *regexp.Regexp
+	monthly  *regexp.Regexp
+	weekly   *regexp.Regexp
+	weekday  *regexp.Regexp
+	workday  *regexp.Regexp
+	daily    *regexp.Regexp
+	relDay   *regexp.Regexp
+	date     *regexp.Regexp
+	clock    *regexp.Regexp
+	subject  *regexp.Regexp
+	holiday  *regexp.Regexp
+	business *regexp.Regexp
+	skip     *regexp.Regexp
+}
+
+func NewScheduleParser() *ScheduleParser {
+	return &ScheduleParser{
+		relative: regexp.MustCompile(`(?:(\d+)日)?(?:(\d+)時間)?(?:(\d+)分)?後[にの]?`),
+		monthly:  regexp.MustCompile(`毎月(?:(\d{1,2})日|(末))[のに]?`),
+		weekly:   regexp.MustCompile(`毎週((?:[月火水木金土日](?:曜日|曜)?[・、,と]?)+)[のに]?`),
+		weekday:  regexp.MustCompile(`[月火水木金土日]`),
+		workday:  regexp.MustCompile(`(?:毎週)?平日[のに]?`),
+		daily:    regexp.MustCompile(`毎日[のに]?`),
+		relDay:   regexp.MustCompile(`(今日|本日|明日|あした|明後日|あさって)[のに]?`),
+		date:     regexp.MustCompile(`(?:(\d{1,2})月)?(\d{1,2})日[のに]?`),
+		clock:    regexp.MustCompile(`(午前|午後|朝|夕方|夜)?(\d{1,2})(?::(\d{2})|時(?:(半)|(\d{1,2})分)?)(?:に|から)?`),
+		subject:  regexp.MustCompile(`(?:を|って|と)?(?:リマインド|知らせ|通知).*$`),
+		holiday:  regexp.MustCompile(`[、,]?(?:祝日|祭日)(?:は|を)?(?:除く|除いて|以外|休み|スキップ)[、,のに]?`),
+		business: regexp.MustCompile(`営業日`),
+		skip:     regexp.MustCompile(`[、,]?(?:(\d{1,2})[/月])?(\d{1,2})日?(?:から|~|〜|-)(?:(\d{1,2})[/月])?(\d{1,2})日?(?:まで)?(?:は|を)?(?:除く|除いて|以外|休み|スキップ)[、,のに]?`),
+	}
+}
+
+// ParseSchedule returns the scheduler found in str relative to now and the rest of str,
+// the rest is the subject of the schedule without the trailing "リマインド".
+// The scheduler is calculated in the location of now.
+func (p *ScheduleParser) ParseSchedule(str string, now time.Time) (model.Scheduler, string, bool) {
+	str = norm.NFKC.String(str)
+
+	if d, rest, ok := p.parseRelative(str); ok {
+		return &model.OneshotScheduler{
+			Time: now.Add(d).Truncate(time.Minute),
+		}, p.parseSubject(rest), true
+	}
+
+	hour, minute, str, hasClock := p.parseClock(str)
+	if !hasClock {
+		hour, minute = defaultScheduleHour, 0
+	}
+	loc := now.Location()
+	year, month, day := now.Date()
+	clock := time.Date(year, month, day, hour, minute, 0, 0, loc)
+
+	if s, rest, ok := p.parseRepeat(str, clock); ok {
+		return s, p.parseSubject(rest), true
+	}
+
+	if t, rest, ok := p.parseDate(str, now); ok {
+		year, month, day := t.Date()
+		return &model.OneshotScheduler{
+			Time: time.Date(year, month, day, hour, minute, 0, 0, loc),
+		}, p.parseSubject(rest), true
+	}
+
+	if !hasClock {
+		return nil, "", false
+	}
+	if !clock.After(now) {
+		clock = clock.AddDate(0, 0, 1)
+	}
+	return &model.OneshotScheduler{Time: clock}, p.parseSubject(str), true
+}
+
+// ParseExclusion returns the exclusion found in str relative to now and the rest of str.
+// The exclusion is nil if str has no exclusion.
+func (p *ScheduleParser) ParseExclusion(str string, now time.Time) (*model.Exclusion, string) {
+	str = norm.NFKC.String(str)
+	exclusion := new(model.Exclusion)
+
+	if m := p.holiday.FindStringIndex(str); m != nil {
+		exclusion.Holidays = true
+		str = cut(str, m[0], m[1])
+	}
+	if p.business.MatchString(str) {
+		exclusion.BusinessDays = true
+		// business days imply a daily schedule
+		str = p.business.ReplaceAllString(str, "毎日")
+	}
+	for {
+		m := p.skip.FindStringSubmatchIndex(str)
+		if m == nil {
+			break
+		}
+		r, ok := parseSkipRange(str, m, now)
+		if !ok {
+			break
+		}
+		exclusion.Ranges = append(exclusion.Ranges, r)
+		str = cut(str, m[0], m[1])
+	}
+
+	if exclusion.Empty() {
+		return nil, str
+	}
+	return exclusion, str
+}
+
+// parseSkipRange parses "8/10から15日は除く" in the match m.
+func parseSkipRange(str string, m []int, now time.Time) (*model.DateRange, bool) {
+	group := func(i int) int {
+		if m[2*i] < 0 {
+			return 0
+		}
+		n, err := strconv.Atoi(str[m[2*i]:m[2*i+1]])
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+
+	loc := now.Location()
+	year, month, _ := now.Date()
+	hasMonth := group(1) != 0
+	if hasMonth {
+		month = time.Month(group(1))
+	}
+	start := time.Date(year, month, group(2), 0, 0, 0, 0, loc)
+	if start.Month() != month {
+		return nil, false
+	}
+	// the date already passed means the next one
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if start.Before(today) {
+		if hasMonth {
+			start = start.AddDate(1, 0, 0)
+		} else {
+			start = start.AddDate(0, 1, 0)
+		}
+	}
+
+	endMonth := start.Month()
+	if n := group(3); n != 0 {
+		endMonth = time.Month(n)
+	}
+	end := time.Date(start.Year(), endMonth, group(4), 0, 0, 0, 0, loc)
+	if end.Month() != endMonth {
+		return nil, false
+	}
+	if end.Before(start) {
+		end = end.AddDate(1, 0, 0)
+	}
+
+	r, err := model.NewDateRange(start, end)
+	if err != nil {
+		return nil, false
+	}
+	return r, true
+}
+
+func (p *ScheduleParser) parseRelative(str string) (time.Duration, string, bool) {
+	for _, m := range p.relative.FindAllStringSubmatchIndex(str, -1) {
+		units := [...]time.Duration{24 * time.Hour, time.Hour, time.Minute}
+		var d time.Duration
+		found := false
+		for i, unit := range units {
+			start, end := m[2*i+2], m[2*i+3]
+			if start < 0 {
+				continue
+			}
+			n, err := strconv.Atoi(str[start:end])
+			if err != nil {
+				continue
+			}
+			d += time.Duration(n) * unit
+			found = true
+		}
+		if found {
+			return d, cut(str, m[0], m[1]), true
+		}
+	}
+	return 0, str, false
+}
+
+func (p *ScheduleParser) parseClock(str string) (hour, minute int, rest string, ok bool) {
+	m := p.clock.FindStringSubmatchIndex(str)
+	if m == nil {
+		return 0, 0, str, false
+	}
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+		return str[m[2*i]:m[2*i+1]]
+	}
+
+	hour, err := strconv.Atoi(group(2))
+	if err != nil {
+		return 0, 0, str, false
+	}
+	switch {
+	case group(3) != "":
+		minute, err = strconv.Atoi(group(3))
+	case group(4) != "":
+		minute = halfHour
+	case group(5) != "":
+		minute, err = strconv.Atoi(group(5))
+	}
+	if err != nil {
+		return 0, 0, str, false
+	}
+
+	switch group(1) {
+	case "午後", "夕方", "夜":
+		if hour < hoursPerHalfDay {
+			hour += hoursPerHalfDay
+		}
+	case "午前", "朝":
+		if hour == hoursPerHalfDay {
+			hour = 0
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, str, false
+	}
+
+	return hour, minute, cut(str, m[0], m[1]), true
+}
+
+func (p *ScheduleParser) parseRepeat(str string, clock time.Time) (model.Scheduler, string, bool) {
+	if m := p.monthly.FindStringSubmatchIndex(str); m != nil {
+		day := lastDayOfMonth
+		if m[2] >= 0 {
+			n, err := strconv.Atoi(str[m[2]:m[3]])
+			if err != nil || n < 1 || n > lastDayOfMonth {
+				return nil, str, false
+			}
+			day = n
+		}
+		return &model.MonthlyScheduler{Time: clock, Day: day}, cut(str, m[0], m[1]), true
+	}
+
+	if m := p.weekly.FindStringSubmatchIndex(str); m != nil {
+		set := make(map[time.Weekday]struct{})
+		for _, w := range p.weekday.FindAllString(str[m[2]:m[3]], -1) {
+			set[japaneseWeekdays[w]] = struct{}{}
+		}
+		weekdays := make([]time.Weekday, 0, len(set))
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if _, ok := set[wd]; ok {
+				weekdays = append(weekdays, wd)
+			}
+		}
+		return &model.WeeklyScheduler{Time: clock, Weekdays: weekdays}, cut(str, m[0], m[1]), true
+	}
+
+	if m := p.workday.FindStringIndex(str); m != nil {
+		return &model.WeeklyScheduler{
+			Time: clock,
+			Weekdays: []time.Weekday{
+				time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+			},
+		}, cut(str, m[0], m[1]), true
+	}
+
+	if m := p.daily.FindStringIndex(str); m != nil {
+		return &model.DailyScheduler{Time: clock}, cut(str, m[0], m[1]), true
+	}
+
+	return nil, str, false
+}
+
+func (p *ScheduleParser) parseDate(str string, now time.Time) (time.Time, string, bool) {
+	if m := p.relDay.FindStringSubmatchIndex(str); m != nil {
+		days := 0
+		switch str[m[2]:m[3]] {
+		case "明日", "あした":
+			days = 1
+		case "明後日", "あさって":
+			days = 2
+		}
+		return now.AddDate(0, 0, days), cut(str, m[0], m[1]), true
+	}
+
+	if m := p.date.FindStringSubmatchIndex(str); m != nil {
+		year, month, _ := now.Date()
+		hasMonth := m[2] >= 0
+		if hasMonth {
+			n, err := strconv.Atoi(str[m[2]:m[3]])
+			if err != nil || n < 1 || n > 12 {
+				return time.Time{}, str, false
+			}
+			month = time.Month(n)
+		}
+		day, err := strconv.Atoi(str[m[4]:m[5]])
+		if err != nil {
+			return time.Time{}, str, false
+		}
+
+		t := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		if t.Day() != day {
+			return time.Time{}, str, false
+		}
+		// the date already passed means the next one
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if t.Before(today) {
+			if hasMonth {
+				t = t.AddDate(1, 0, 0)
+			} else {
+				t = t.AddDate(0, 1, 0)
+			}
+		}
+		return t, cut(str, m[0], m[1]), true
+	}
+
+	return time.Time{}, str, false
+}
+
+func (p *ScheduleParser) parseSubject(str string) string {
+	str = p.subject.ReplaceAllString(str, "")
+	return strings.Trim(str, " 、,。.")
+}
+
+func cut(str string, start, end int) string {
+	return str[:start] + str[end:]
+}
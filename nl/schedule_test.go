@@ -0,0 +1,219 @@
+package nl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ww24/linebot/domain/model"
+)
+
+func TestScheduleParser_ParseSchedule(t *testing.T) {
+	t.Parallel()
+	p := NewScheduleParser()
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// Wednesday
+	now := time.Date(2021, 4, 7, 10, 15, 30, 0, loc)
+
+	tests := []struct {
+		src         string
+		want        model.Scheduler
+		wantSubject string
+		wantOK      bool
+	}{
+		{
+			src: "明日の8時に買い物リストをリマインド",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 8, 8, 0, 0, 0, loc),
+			},
+			wantSubject: "買い物リスト",
+			wantOK:      true,
+		},
+		{
+			src: "毎週月曜19時",
+			want: &model.WeeklyScheduler{
+				Time:     time.Date(2021, 4, 7, 19, 0, 0, 0, loc),
+				Weekdays: []time.Weekday{time.Monday},
+			},
+			wantOK: true,
+		},
+		{
+			src: "30分後",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 7, 10, 45, 0, 0, loc),
+			},
+			wantOK: true,
+		},
+		{
+			src: "1時間30分後にゴミ出しをリマインドして",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 7, 11, 45, 0, 0, loc),
+			},
+			wantSubject: "ゴミ出し",
+			wantOK:      true,
+		},
+		{
+			src: "毎日７：３０に天気をリマインド",
+			want: &model.DailyScheduler{
+				Time: time.Date(2021, 4, 7, 7, 30, 0, 0, loc),
+			},
+			wantSubject: "天気",
+			wantOK:      true,
+		},
+		{
+			src: "毎週火・金の夜8時半に燃えるゴミって通知して",
+			want: &model.WeeklyScheduler{
+				Time:     time.Date(2021, 4, 7, 20, 30, 0, 0, loc),
+				Weekdays: []time.Weekday{time.Tuesday, time.Friday},
+			},
+			wantSubject: "燃えるゴミ",
+			wantOK:      true,
+		},
+		{
+			src: "平日の朝7時に起きるとリマインド",
+			want: &model.WeeklyScheduler{
+				Time: time.Date(2021, 4, 7, 7, 0, 0, 0, loc),
+				Weekdays: []time.Weekday{
+					time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+				},
+			},
+			wantSubject: "起きる",
+			wantOK:      true,
+		},
+		{
+			src: "毎月25日に家賃をリマインド",
+			want: &model.MonthlyScheduler{
+				Time: time.Date(2021, 4, 7, 9, 0, 0, 0, loc),
+				Day:  25,
+			},
+			wantSubject: "家賃",
+			wantOK:      true,
+		},
+		{
+			src: "毎月末の午後3時",
+			want: &model.MonthlyScheduler{
+				Time: time.Date(2021, 4, 7, 15, 0, 0, 0, loc),
+				Day:  31,
+			},
+			wantOK: true,
+		},
+		{
+			src: "4月1日15時15分に更新をリマインド",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2022, 4, 1, 15, 15, 0, 0, loc),
+			},
+			wantSubject: "更新",
+			wantOK:      true,
+		},
+		{
+			src: "20日に振込",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 20, 9, 0, 0, 0, loc),
+			},
+			wantSubject: "振込",
+			wantOK:      true,
+		},
+		{
+			src: "9時に薬",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 8, 9, 0, 0, 0, loc),
+			},
+			wantSubject: "薬",
+			wantOK:      true,
+		},
+		{
+			src: "11時に薬",
+			want: &model.OneshotScheduler{
+				Time: time.Date(2021, 4, 7, 11, 0, 0, 0, loc),
+			},
+			wantSubject: "薬",
+			wantOK:      true,
+		},
+		{
+			src:    "買い物リストをリマインド",
+			want:   nil,
+			wantOK: false,
+		},
+		{
+			src:    "25時に薬",
+			want:   nil,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got, subject, ok := p.ParseSchedule(tt.src, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSubject, subject)
+		})
+	}
+}
+
+func TestScheduleParser_ParseExclusion(t *testing.T) {
+	t.Parallel()
+	p := NewScheduleParser()
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// Wednesday
+	now := time.Date(2021, 4, 7, 10, 15, 30, 0, loc)
+	dateRange := func(sm, sd, em, ed int, years ...int) *model.DateRange {
+		sy, ey := 2021, 2021
+		if len(years) == 2 {
+			sy, ey = years[0], years[1]
+		}
+		r, err := model.NewDateRange(time.Date(sy, time.Month(sm), sd, 0, 0, 0, 0, loc), time.Date(ey, time.Month(em), ed, 0, 0, 0, 0, loc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		src      string
+		want     *model.Exclusion
+		wantRest string
+	}{
+		{
+			src:      "明日の8時に買い物リストをリマインド",
+			want:     nil,
+			wantRest: "明日の8時に買い物リストをリマインド",
+		},
+		{
+			src:      "毎日8時に祝日を除いて買い物リストをリマインド",
+			want:     &model.Exclusion{Holidays: true},
+			wantRest: "毎日8時に買い物リストをリマインド",
+		},
+		{
+			src:      "営業日の9時に天気をリマインド",
+			want:     &model.Exclusion{BusinessDays: true},
+			wantRest: "毎日の9時に天気をリマインド",
+		},
+		{
+			src:      "毎日8時に買い物リスト、8月10日から8月15日は除くでリマインド",
+			want:     &model.Exclusion{Ranges: []*model.DateRange{dateRange(8, 10, 8, 15)}},
+			wantRest: "毎日8時に買い物リストでリマインド",
+		},
+		{
+			src:      "毎日8時に買い物リスト、4/1〜4/3は休み、12/30から1/3はスキップでリマインド",
+			want:     &model.Exclusion{Ranges: []*model.DateRange{dateRange(4, 1, 4, 3, 2022, 2022), dateRange(12, 30, 1, 3, 2021, 2022)}},
+			wantRest: "毎日8時に買い物リストでリマインド",
+		},
+		{
+			src:      "毎日8時に薬、10日から12日は除く",
+			want:     &model.Exclusion{Ranges: []*model.DateRange{dateRange(4, 10, 4, 12)}},
+			wantRest: "毎日8時に薬",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.src, func(t *testing.T) {
+			t.Parallel()
+			got, rest := p.ParseExclusion(tt.src, now)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}